@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// AgingItem — элемент в работе, который находится в текущем состоянии дольше нормы.
+type AgingItem struct {
+	ID         int                 `json:"id"`
+	Name       string              `json:"name"`
+	Type       domain.WorkItemType `json:"type"`
+	URL        string              `json:"url,omitempty"`
+	State      string              `json:"state"`
+	AssignedTo string              `json:"assignedTo"`
+	Since      time.Time           `json:"since"`
+	AgeDays    float64             `json:"ageDays"`
+}
+
+// AgingWIP — стареющая незавершённая работа.
+type AgingWIP struct {
+	// ThresholdDays — 85-й процентиль cycle time команды.
+	ThresholdDays float64     `json:"thresholdDays"`
+	Items         []AgingItem `json:"items"`
+}
+
+// AnalyzeAging находит элементы спринта в работе, чей возраст в текущем состоянии
+// превышает 85-й процентиль cycle time из flow. Результат отсортирован от самых старых.
+// Возвращает nil, если статистики cycle time ещё нет.
+func AnalyzeAging(sprint *domain.Sprint, flow *Flow, now time.Time) *AgingWIP {
+	if sprint == nil || flow == nil || flow.CycleTime.Count == 0 {
+		return nil
+	}
+
+	res := &AgingWIP{ThresholdDays: flow.CycleTime.P85, Items: []AgingItem{}}
+	for _, wi := range sprint.WorkItems {
+		if !isWorkItemLevel(wi) || !isInProgress(wi) {
+			continue
+		}
+
+		since := stateSince(wi)
+		if since == nil {
+			continue
+		}
+		age := days(now.Sub(*since))
+		if age <= res.ThresholdDays {
+			continue
+		}
+
+		assignee := wi.AssignedTo
+		if assignee == "" {
+			assignee = Unassigned
+		}
+		res.Items = append(res.Items, AgingItem{
+			ID:         wi.ID,
+			Name:       wi.Name,
+			Type:       wi.Type,
+			URL:        wi.URL,
+			State:      wi.State,
+			AssignedTo: assignee,
+			Since:      *since,
+			AgeDays:    age,
+		})
+	}
+
+	sort.Slice(res.Items, func(i, j int) bool { return res.Items[i].AgeDays > res.Items[j].AgeDays })
+
+	return res
+}
+
+// stateSince — момент перехода элемента в текущее состояние.
+func stateSince(wi domain.WorkItem) *time.Time {
+	if n := len(wi.Transitions); n > 0 {
+		return &wi.Transitions[n-1].At
+	}
+	return wi.StateChangeDate
+}
@@ -1 +1,53 @@
 package analysis
+
+import (
+	"math"
+	"sort"
+
+	"scrum-eye/internal/domain"
+)
+
+// isInProgress — элемент взят в работу, но ещё не закрыт.
+func isInProgress(wi domain.WorkItem) bool {
+	return wi.StateCategory == domain.StateInProgress || wi.StateCategory == domain.StateResolved
+}
+
+// isOpen — элемент не закрыт и не удалён.
+func isOpen(wi domain.WorkItem) bool {
+	return wi.StateCategory != domain.StateCompleted && wi.StateCategory != domain.StateRemoved
+}
+
+// isWorkItemLevel — элемент уровня спринта (эпики и фичи в WIP не учитываются).
+func isWorkItemLevel(wi domain.WorkItem) bool {
+	return wi.Type != domain.WorkItemEpic && wi.Type != domain.WorkItemFeature
+}
+
+// percentile возвращает p-й процентиль (0..100) с линейной интерполяцией.
+// Срез values сортируется на месте.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sort.Float64s(values)
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	rank := p / 100 * float64(len(values)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return values[lo] + (values[hi]-values[lo])*(rank-float64(lo))
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
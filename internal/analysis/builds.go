@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// DefaultBuildWindow — размер окна сборок, если metrics.maxBuilds не задан.
+const DefaultBuildWindow = 20
+
+// BuildHealth — состояние одной конфигурации сборки за последнее окно из maxBuilds сборок.
+type BuildHealth struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	Branch        string             `json:"branch,omitempty"`
+	CurrentStatus domain.BuildStatus `json:"currentStatus"`
+	LastBuildAt   *time.Time         `json:"lastBuildAt,omitempty"`
+	// RedSince — время первой упавшей сборки текущей серии, если сборка сейчас красная.
+	RedSince    *time.Time    `json:"redSince,omitempty"`
+	RedFor      time.Duration `json:"redFor"`
+	Builds      int           `json:"builds"`
+	Successful  int           `json:"successful"`
+	SuccessRate float64       `json:"successRate"`
+	// MTTR — среднее время от первой красной сборки до следующей зелёной.
+	MTTR               time.Duration `json:"mttr"`
+	Recoveries         int           `json:"recoveries"`
+	LongestRedStreak   int           `json:"longestRedStreak"`
+	LongestRedDuration time.Duration `json:"longestRedDuration"`
+	AvgDuration        time.Duration `json:"avgDuration"`
+	P90Duration        time.Duration `json:"p90Duration"`
+	PrevAvgDuration    time.Duration `json:"prevAvgDuration"`
+	PrevP90Duration    time.Duration `json:"prevP90Duration"`
+	// DurationTrend — относительное изменение средней длительности к предыдущему окну (0.1 = +10%).
+	DurationTrend float64 `json:"durationTrend"`
+}
+
+// IsRed сообщает, что последняя сборка конфигурации упала.
+func (h BuildHealth) IsRed() bool {
+	return h.CurrentStatus == domain.BuildFailure
+}
+
+// AnalyzeBuilds считает здоровье сборок. Сборки в конфигурациях идут от новых к старым;
+// первые window сборок — текущее окно, следующие window — предыдущее, для тренда длительности.
+func AnalyzeBuilds(configs []domain.BuildConfiguration, window int, now time.Time) []BuildHealth {
+	if window <= 0 {
+		window = DefaultBuildWindow
+	}
+
+	result := make([]BuildHealth, 0, len(configs))
+	for _, bc := range configs {
+		current := bc.Builds
+		var previous []domain.Build
+		if len(current) > window {
+			previous = current[window:min(len(current), 2*window)]
+			current = current[:window]
+		}
+
+		h := BuildHealth{
+			ID:            bc.ID,
+			Name:          bc.Name,
+			Branch:        bc.Branch,
+			CurrentStatus: domain.BuildUnknown,
+			Builds:        len(current),
+		}
+
+		if len(current) > 0 {
+			h.CurrentStatus = current[0].Status
+			h.LastBuildAt = buildTime(current[0])
+		}
+
+		analyzeStreaks(&h, current, now)
+
+		h.AvgDuration, h.P90Duration = durationStats(current)
+		h.PrevAvgDuration, h.PrevP90Duration = durationStats(previous)
+		if h.PrevAvgDuration > 0 {
+			h.DurationTrend = float64(h.AvgDuration-h.PrevAvgDuration) / float64(h.PrevAvgDuration)
+		}
+
+		result = append(result, h)
+	}
+
+	return result
+}
+
+// analyzeStreaks проходит сборки в хронологическом порядке и считает
+// успешность, серии падений и время восстановления.
+func analyzeStreaks(h *BuildHealth, builds []domain.Build, now time.Time) {
+	var (
+		redStart    *time.Time
+		streak      int
+		recoverySum time.Duration
+	)
+
+	for i := len(builds) - 1; i >= 0; i-- {
+		b := builds[i]
+		at := buildTime(b)
+
+		switch b.Status {
+		case domain.BuildSuccess:
+			h.Successful++
+			if streak > 0 && redStart != nil && at != nil {
+				recovery := at.Sub(*redStart)
+				recoverySum += recovery
+				h.Recoveries++
+				h.LongestRedDuration = max(h.LongestRedDuration, recovery)
+			}
+			streak = 0
+			redStart = nil
+		case domain.BuildFailure:
+			if streak == 0 {
+				redStart = at
+			}
+			streak++
+			h.LongestRedStreak = max(h.LongestRedStreak, streak)
+		}
+	}
+
+	if h.Builds > 0 {
+		h.SuccessRate = float64(h.Successful) / float64(h.Builds)
+	}
+	if h.Recoveries > 0 {
+		h.MTTR = recoverySum / time.Duration(h.Recoveries)
+	}
+
+	if streak > 0 && redStart != nil {
+		h.RedSince = redStart
+		h.RedFor = now.Sub(*redStart)
+		h.LongestRedDuration = max(h.LongestRedDuration, h.RedFor)
+	}
+}
+
+func durationStats(builds []domain.Build) (avg, p90 time.Duration) {
+	values := make([]float64, 0, len(builds))
+	for _, b := range builds {
+		if b.Duration > 0 {
+			values = append(values, float64(b.Duration))
+		}
+	}
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	return time.Duration(mean(values)), time.Duration(percentile(values, 90))
+}
+
+// buildTime — момент, на который сборка отражает состояние ветки.
+func buildTime(b domain.Build) *time.Time {
+	if b.FinishedAt != nil {
+		return b.FinishedAt
+	}
+	return b.StartedAt
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// SprintSnapshot — состояние спринта на момент At (из сохранённого снимка или текущего запуска).
+type SprintSnapshot struct {
+	At     time.Time
+	Sprint *domain.Sprint
+}
+
+// BurndownPoint — состояние спринта на конец одного дня.
+type BurndownPoint struct {
+	Date time.Time `json:"date"`
+	// HasData — за этот день есть снимок; иначе значения пустые.
+	HasData         bool    `json:"hasData"`
+	RemainingWork   float64 `json:"remainingWork"`
+	RemainingPoints float64 `json:"remainingPoints"`
+	CompletedPoints float64 `json:"completedPoints"`
+	ScopePoints     float64 `json:"scopePoints"`
+	IdealPoints     float64 `json:"idealPoints"`
+	IdealWork       float64 `json:"idealWork"`
+}
+
+// Burndown — сгорание и объём спринта по дням.
+type Burndown struct {
+	Start  time.Time       `json:"start"`
+	End    time.Time       `json:"end"`
+	Points []BurndownPoint `json:"points"`
+}
+
+// AnalyzeBurndown строит сгорание по дням спринта из снимков: для каждого дня
+// берётся последний снимок этого дня. Идеальная линия идёт от объёма первого дня
+// с данными до нуля к дате окончания и не снижается в выходные.
+// Возвращает nil, если у спринта нет дат.
+func AnalyzeBurndown(sprint *domain.Sprint, snapshots []SprintSnapshot, now time.Time) *Burndown {
+	if sprint == nil || sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil
+	}
+
+	loc := now.Location()
+	start := startOfDay(sprint.StartDate.In(loc))
+	end := startOfDay(sprint.EndDate.In(loc))
+	if end.Before(start) {
+		return nil
+	}
+
+	// последний снимок нужного спринта за каждый день
+	byDay := map[time.Time]SprintSnapshot{}
+	for _, s := range snapshots {
+		if s.Sprint == nil || s.Sprint.ID != sprint.ID {
+			continue
+		}
+		day := startOfDay(s.At.In(loc))
+		if prev, ok := byDay[day]; !ok || s.At.After(prev.At) {
+			byDay[day] = s
+		}
+	}
+
+	bd := &Burndown{Start: start, End: end}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		p := BurndownPoint{Date: day}
+		if s, ok := byDay[day]; ok {
+			p.HasData = true
+			p.RemainingWork, p.RemainingPoints, p.CompletedPoints, p.ScopePoints = sprintTotals(s.Sprint)
+		}
+		bd.Points = append(bd.Points, p)
+	}
+
+	fillIdealLine(bd)
+	return bd
+}
+
+// sprintTotals считает остаток работы, остаток и выполненные story points и объём спринта.
+func sprintTotals(sprint *domain.Sprint) (remainingWork, remainingPoints, completedPoints, scopePoints float64) {
+	for _, wi := range sprint.WorkItems {
+		if wi.StateCategory == domain.StateRemoved {
+			continue
+		}
+
+		scopePoints += wi.StoryPoints
+		if wi.StateCategory == domain.StateCompleted {
+			completedPoints += wi.StoryPoints
+			continue
+		}
+
+		remainingPoints += wi.StoryPoints
+		remainingWork += wi.RemainingWork
+	}
+	return remainingWork, remainingPoints, completedPoints, scopePoints
+}
+
+func fillIdealLine(bd *Burndown) {
+	var basePoints, baseWork float64
+	for _, p := range bd.Points {
+		if p.HasData {
+			basePoints, baseWork = p.RemainingPoints, p.RemainingWork
+			break
+		}
+	}
+
+	workingDays := 0
+	for _, p := range bd.Points[1:] {
+		if isWorkingDay(p.Date) {
+			workingDays++
+		}
+	}
+
+	burned := 0
+	for i := range bd.Points {
+		if i > 0 && isWorkingDay(bd.Points[i].Date) {
+			burned++
+		}
+
+		left := 1.0
+		if workingDays > 0 {
+			left = 1 - float64(burned)/float64(workingDays)
+		}
+		bd.Points[i].IdealPoints = basePoints * left
+		bd.Points[i].IdealWork = baseWork * left
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func isWorkingDay(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
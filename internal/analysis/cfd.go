@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// Источники точки CFD.
+const (
+	CFDSourceSnapshot = "snapshot"
+	CFDSourceHistory  = "history"
+)
+
+// cfdCategories — порядок слоёв диаграммы снизу вверх: сделанное внизу, новое наверху.
+var cfdCategories = []domain.StateCategory{
+	domain.StateCompleted,
+	domain.StateResolved,
+	domain.StateInProgress,
+	domain.StateProposed,
+	domain.StateUnknown,
+}
+
+// CFDPoint — количество элементов по категориям и состояниям на конец дня.
+type CFDPoint struct {
+	Date time.Time `json:"date"`
+	// Source — откуда взяты данные: snapshot, history или пусто, если данных за день нет.
+	Source     string                       `json:"source,omitempty"`
+	Categories map[domain.StateCategory]int `json:"categories"`
+	States     map[string]int               `json:"states"`
+}
+
+// HasData сообщает, что за день есть данные.
+func (p CFDPoint) HasData() bool {
+	return p.Source != ""
+}
+
+// CFD — данные накопительной диаграммы потока за спринт.
+type CFD struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Categories и States — порядок слоёв диаграммы снизу вверх.
+	Categories []domain.StateCategory `json:"categories"`
+	States     []string               `json:"states"`
+	// StateCategories — категория каждого состояния из States.
+	StateCategories map[string]domain.StateCategory `json:"stateCategories"`
+	Points          []CFDPoint                      `json:"points"`
+}
+
+// AnalyzeCFD считает элементы спринта по состояниям на конец каждого дня до сегодняшнего.
+// Для дня берётся последний снимок; если снимка нет, состояние восстанавливается
+// по истории переходов элементов текущего спринта: элемент учитывается с того дня,
+// когда он попал в спринт (AddedToSprintAt). Удалённые элементы не учитываются.
+// Возвращает nil, если у спринта нет дат.
+func AnalyzeCFD(sprint *domain.Sprint, snapshots []SprintSnapshot, now time.Time) *CFD {
+	if sprint == nil || sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil
+	}
+
+	loc := now.Location()
+	start := startOfDay(sprint.StartDate.In(loc))
+	end := startOfDay(sprint.EndDate.In(loc))
+	if end.Before(start) {
+		return nil
+	}
+
+	byDay := map[time.Time]SprintSnapshot{}
+	for _, s := range snapshots {
+		if s.Sprint == nil || s.Sprint.ID != sprint.ID {
+			continue
+		}
+		day := startOfDay(s.At.In(loc))
+		if prev, ok := byDay[day]; !ok || s.At.After(prev.At) {
+			byDay[day] = s
+		}
+	}
+
+	cfd := &CFD{Start: start, End: end, Categories: cfdCategories, StateCategories: map[string]domain.StateCategory{}}
+	stateCategory := cfd.StateCategories
+	stateOrder := make([]string, 0)
+
+	count := func(p *CFDPoint, state string, category domain.StateCategory) {
+		if category == domain.StateRemoved {
+			return
+		}
+		p.Categories[category]++
+		p.States[state]++
+		if _, ok := stateCategory[state]; !ok {
+			stateCategory[state] = category
+			stateOrder = append(stateOrder, state)
+		}
+	}
+
+	today := startOfDay(now)
+	for day := start; !day.After(end) && !day.After(today); day = day.AddDate(0, 0, 1) {
+		p := CFDPoint{Date: day, Categories: map[domain.StateCategory]int{}, States: map[string]int{}}
+
+		if s, ok := byDay[day]; ok {
+			p.Source = CFDSourceSnapshot
+			for _, wi := range s.Sprint.WorkItems {
+				count(&p, wi.State, wi.StateCategory)
+			}
+		} else {
+			dayEnd := day.AddDate(0, 0, 1)
+			for _, wi := range sprint.WorkItems {
+				if wi.AddedToSprintAt != nil && !wi.AddedToSprintAt.Before(dayEnd) {
+					continue
+				}
+				tr := transitionAt(wi, dayEnd)
+				if tr == nil {
+					continue
+				}
+				p.Source = CFDSourceHistory
+				count(&p, tr.To, tr.Category)
+			}
+		}
+
+		cfd.Points = append(cfd.Points, p)
+	}
+
+	for _, c := range cfdCategories {
+		for _, state := range stateOrder {
+			if stateCategory[state] == c {
+				cfd.States = append(cfd.States, state)
+			}
+		}
+	}
+
+	return cfd
+}
+
+// transitionAt — последний переход элемента до момента t.
+func transitionAt(wi domain.WorkItem, t time.Time) *domain.StateTransition {
+	var last *domain.StateTransition
+	for i := range wi.Transitions {
+		if !wi.Transitions[i].At.Before(t) {
+			break
+		}
+		last = &wi.Transitions[i]
+	}
+	return last
+}
@@ -0,0 +1,146 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// FlowItem — время прохождения одного завершённого элемента; точка для диаграммы рассеяния.
+type FlowItem struct {
+	ID          int                 `json:"id"`
+	Name        string              `json:"name"`
+	Type        domain.WorkItemType `json:"type"`
+	CompletedAt time.Time           `json:"completedAt"`
+	// CycleTimeDays — от первого перехода в работу до завершения; 0, если элемент не брался в работу.
+	CycleTimeDays float64 `json:"cycleTimeDays"`
+	// LeadTimeDays — от создания до завершения.
+	LeadTimeDays float64 `json:"leadTimeDays"`
+}
+
+// FlowStats — процентили времени прохождения в днях.
+type FlowStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P85   float64 `json:"p85"`
+	P95   float64 `json:"p95"`
+}
+
+// TypeFlow — статистика по одному типу элементов.
+type TypeFlow struct {
+	Type      domain.WorkItemType `json:"type"`
+	CycleTime FlowStats           `json:"cycleTime"`
+	LeadTime  FlowStats           `json:"leadTime"`
+}
+
+// Flow — cycle time и lead time по завершённым элементам текущего и прошлых спринтов.
+type Flow struct {
+	CycleTime FlowStats  `json:"cycleTime"`
+	LeadTime  FlowStats  `json:"leadTime"`
+	ByType    []TypeFlow `json:"byType"`
+	Items     []FlowItem `json:"items"`
+}
+
+// AnalyzeFlow считает cycle time и lead time завершённых элементов уровня спринта.
+// Даты переходов берутся из истории состояний, а если её нет — из ActivatedDate и ClosedDate.
+// Возвращает nil, если завершённых элементов нет.
+func AnalyzeFlow(sprints []domain.Sprint) *Flow {
+	items := make([]FlowItem, 0)
+	seen := map[int]bool{}
+
+	for _, sprint := range sprints {
+		for _, wi := range sprint.WorkItems {
+			if seen[wi.ID] || !isWorkItemLevel(wi) || wi.StateCategory != domain.StateCompleted {
+				continue
+			}
+			item, ok := flowItem(wi)
+			if !ok {
+				continue
+			}
+			seen[wi.ID] = true
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].CompletedAt.Before(items[j].CompletedAt) })
+
+	f := &Flow{Items: items}
+	f.CycleTime, f.LeadTime = flowStats(items)
+
+	byType := map[domain.WorkItemType][]FlowItem{}
+	for _, it := range items {
+		byType[it.Type] = append(byType[it.Type], it)
+	}
+	for t, list := range byType {
+		tf := TypeFlow{Type: t}
+		tf.CycleTime, tf.LeadTime = flowStats(list)
+		f.ByType = append(f.ByType, tf)
+	}
+	sort.Slice(f.ByType, func(i, j int) bool { return f.ByType[i].Type < f.ByType[j].Type })
+
+	return f
+}
+
+// flowItem находит даты начала работы и завершения элемента.
+func flowItem(wi domain.WorkItem) (FlowItem, bool) {
+	started, done := wi.ActivatedDate, wi.ClosedDate
+	if len(wi.Transitions) > 0 {
+		started, done = nil, nil
+		for i := range wi.Transitions {
+			tr := &wi.Transitions[i]
+			if started == nil && (tr.Category == domain.StateInProgress || tr.Category == domain.StateResolved) {
+				started = &tr.At
+			}
+			// после переоткрытия учитываем последнее завершение
+			if tr.Category == domain.StateCompleted {
+				done = &tr.At
+			}
+		}
+	}
+	if done == nil {
+		return FlowItem{}, false
+	}
+
+	item := FlowItem{ID: wi.ID, Name: wi.Name, Type: wi.Type, CompletedAt: *done}
+	if started != nil && !started.After(*done) {
+		item.CycleTimeDays = days(done.Sub(*started))
+	}
+	if wi.CreatedDate != nil && !wi.CreatedDate.After(*done) {
+		item.LeadTimeDays = days(done.Sub(*wi.CreatedDate))
+	}
+
+	return item, true
+}
+
+func flowStats(items []FlowItem) (cycle, lead FlowStats) {
+	cycleDays := make([]float64, 0, len(items))
+	leadDays := make([]float64, 0, len(items))
+	for _, it := range items {
+		if it.CycleTimeDays > 0 {
+			cycleDays = append(cycleDays, it.CycleTimeDays)
+		}
+		if it.LeadTimeDays > 0 {
+			leadDays = append(leadDays, it.LeadTimeDays)
+		}
+	}
+	return newFlowStats(cycleDays), newFlowStats(leadDays)
+}
+
+func newFlowStats(values []float64) FlowStats {
+	return FlowStats{
+		Count: len(values),
+		Mean:  mean(values),
+		P50:   percentile(values, 50),
+		P85:   percentile(values, 85),
+		P95:   percentile(values, 95),
+	}
+}
+
+func days(d time.Duration) float64 {
+	return d.Hours() / 24
+}
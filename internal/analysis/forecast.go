@@ -0,0 +1,165 @@
+package analysis
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// DefaultForecastTrials — число прогонов симуляции по умолчанию.
+const DefaultForecastTrials = 10000
+
+// maxForecastDays ограничивает один прогон, чтобы не зациклиться при почти нулевой пропускной способности.
+const maxForecastDays = 365
+
+// ForecastOptions — параметры симуляции. Нулевой Seed означает случайное зерно.
+type ForecastOptions struct {
+	Trials int
+	Seed   int64
+}
+
+// Forecast — вероятностный прогноз завершения спринта методом Монте-Карло.
+type Forecast struct {
+	RemainingItems int        `json:"remainingItems"`
+	SampleDays     int        `json:"sampleDays"`
+	Trials         int        `json:"trials"`
+	EndDate        *time.Time `json:"endDate,omitempty"`
+	// Probability — доля прогонов, в которых всё оставшееся закрыто к EndDate.
+	Probability float64   `json:"probability"`
+	P50         time.Time `json:"p50"`
+	P85         time.Time `json:"p85"`
+	P95         time.Time `json:"p95"`
+}
+
+// ForecastCompletion моделирует, когда будут закрыты оставшиеся элементы бэклога текущего спринта.
+// Пропускная способность (элементов за рабочий день) берётся из закрытых элементов прошлых спринтов
+// и уже прошедших дней текущего. Возвращает nil, если истории нет или в ней ничего не закрывалось.
+func ForecastCompletion(sprint *domain.Sprint, history []domain.Sprint, now time.Time, opts ForecastOptions) *Forecast {
+	if sprint == nil {
+		return nil
+	}
+
+	remaining := 0
+	for _, wi := range sprint.WorkItems {
+		if isBacklogItem(wi) && isOpen(wi) {
+			remaining++
+		}
+	}
+
+	today := startOfDay(now)
+	samples := make([]int, 0)
+	for _, s := range history {
+		samples = append(samples, dailyThroughput(s, today)...)
+	}
+	samples = append(samples, dailyThroughput(*sprint, today)...)
+
+	f := &Forecast{RemainingItems: remaining, SampleDays: len(samples), EndDate: sprint.EndDate}
+
+	if remaining == 0 {
+		f.Probability = 1
+		f.P50, f.P85, f.P95 = today, today, today
+		return f
+	}
+	if !hasThroughput(samples) {
+		return nil
+	}
+
+	trials := opts.Trials
+	if trials <= 0 {
+		trials = DefaultForecastTrials
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = now.UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	var deadline time.Time
+	if sprint.EndDate != nil {
+		deadline = startOfDay(sprint.EndDate.In(now.Location()))
+	}
+
+	finishes := make([]time.Time, 0, trials)
+	onTime := 0
+	for i := 0; i < trials; i++ {
+		finish := simulateFinish(remaining, samples, today, rnd)
+		finishes = append(finishes, finish)
+		if !deadline.IsZero() && !finish.After(deadline) {
+			onTime++
+		}
+	}
+
+	sort.Slice(finishes, func(i, j int) bool { return finishes[i].Before(finishes[j]) })
+
+	f.Trials = trials
+	f.Probability = float64(onTime) / float64(trials)
+	f.P50 = finishes[percentileIndex(len(finishes), 50)]
+	f.P85 = finishes[percentileIndex(len(finishes), 85)]
+	f.P95 = finishes[percentileIndex(len(finishes), 95)]
+
+	return f
+}
+
+// simulateFinish — один прогон: по рабочим дням начиная с today вычитаем
+// случайную дневную пропускную способность, пока не закроем всё.
+func simulateFinish(remaining int, samples []int, today time.Time, rnd *rand.Rand) time.Time {
+	day := today
+	for i := 0; i < maxForecastDays; i++ {
+		if isWorkingDay(day) {
+			remaining -= samples[rnd.Intn(len(samples))]
+			if remaining <= 0 {
+				return day
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// dailyThroughput — количество закрытых элементов бэклога за каждый рабочий день спринта до before.
+func dailyThroughput(sprint domain.Sprint, before time.Time) []int {
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil
+	}
+
+	loc := before.Location()
+	closed := map[time.Time]int{}
+	for _, wi := range sprint.WorkItems {
+		if !isBacklogItem(wi) || wi.StateCategory != domain.StateCompleted {
+			continue
+		}
+		at := wi.ClosedDate
+		if at == nil {
+			at = wi.StateChangeDate
+		}
+		if at != nil {
+			closed[startOfDay(at.In(loc))]++
+		}
+	}
+
+	result := make([]int, 0)
+	end := startOfDay(sprint.EndDate.In(loc))
+	for day := startOfDay(sprint.StartDate.In(loc)); !day.After(end) && day.Before(before); day = day.AddDate(0, 0, 1) {
+		if isWorkingDay(day) {
+			result = append(result, closed[day])
+		}
+	}
+	return result
+}
+
+func hasThroughput(samples []int) bool {
+	for _, s := range samples {
+		if s > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// percentileIndex — индекс p-го процентиля в отсортированном срезе длины n.
+func percentileIndex(n int, p float64) int {
+	idx := int(float64(n)*p/100+0.5) - 1
+	return max(0, min(n-1, idx))
+}
@@ -0,0 +1,130 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// march — день марта 2024 в UTC; 4 марта — понедельник.
+func march(d int) time.Time {
+	return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+// pastSprint — прошлый спринт 19.02–01.03 (10 рабочих дней), в котором в i-й рабочий день
+// закрыто closed[i] историй.
+func pastSprint(closed ...int) domain.Sprint {
+	start := time.Date(2024, 2, 19, 0, 0, 0, 0, time.UTC)
+	s := domain.Sprint{ID: "past", StartDate: ptr(start), EndDate: ptr(march(1))}
+
+	day := start
+	for _, n := range closed {
+		for !isWorkingDay(day) {
+			day = day.AddDate(0, 0, 1)
+		}
+		for j := 0; j < n; j++ {
+			s.WorkItems = append(s.WorkItems, domain.WorkItem{
+				ID: len(s.WorkItems) + 1, Type: domain.WorkItemStory,
+				StateCategory: domain.StateCompleted, ClosedDate: ptr(day.Add(15 * time.Hour)),
+			})
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return s
+}
+
+// currentSprint — спринт 04.03–end с open открытыми историями и задачей, которая в прогноз не входит.
+func currentSprint(open int, end time.Time) *domain.Sprint {
+	s := &domain.Sprint{ID: "current", StartDate: ptr(march(4)), EndDate: ptr(end)}
+	for i := 0; i < open; i++ {
+		s.WorkItems = append(s.WorkItems, domain.WorkItem{ID: 100 + i, Type: domain.WorkItemStory, StateCategory: domain.StateInProgress})
+	}
+	s.WorkItems = append(s.WorkItems, domain.WorkItem{ID: 200, Type: domain.WorkItemTask, StateCategory: domain.StateProposed})
+	return s
+}
+
+var forecastNow = march(4).Add(10 * time.Hour)
+
+func TestForecastConstantThroughput(t *testing.T) {
+	history := []domain.Sprint{pastSprint(1, 1, 1, 1, 1, 1, 1, 1, 1, 1)}
+
+	tests := []struct {
+		name        string
+		end         time.Time
+		probability float64
+	}{
+		{"deadline after the finish", march(15), 1},
+		{"deadline on the finish day", march(6), 1},
+		{"deadline before the finish", march(5), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := ForecastCompletion(currentSprint(3, tt.end), history, forecastNow, ForecastOptions{Trials: 100, Seed: 1})
+			if f == nil {
+				t.Fatal("ForecastCompletion = nil, want a forecast")
+			}
+
+			if f.RemainingItems != 3 || f.SampleDays != 10 || f.Trials != 100 {
+				t.Errorf("remaining, samples, trials = %d, %d, %d, want 3, 10, 100", f.RemainingItems, f.SampleDays, f.Trials)
+			}
+			// по одной истории в день: понедельник, вторник, среда
+			for name, p := range map[string]time.Time{"P50": f.P50, "P85": f.P85, "P95": f.P95} {
+				if !p.Equal(march(6)) {
+					t.Errorf("%s = %v, want %v", name, p, march(6))
+				}
+			}
+			if f.Probability != tt.probability {
+				t.Errorf("Probability = %v, want %v", f.Probability, tt.probability)
+			}
+		})
+	}
+}
+
+func TestForecastSeeded(t *testing.T) {
+	// в половину дней закрывается по две истории, в остальные — ничего
+	history := []domain.Sprint{pastSprint(2, 0, 2, 0, 2, 0, 2, 0, 2, 0)}
+	opts := ForecastOptions{Trials: 2000, Seed: 42}
+
+	sprint := currentSprint(6, march(8))
+
+	f := ForecastCompletion(sprint, history, forecastNow, opts)
+	if f == nil {
+		t.Fatal("ForecastCompletion = nil, want a forecast")
+	}
+
+	if !f.P50.Equal(march(8)) || !f.P85.Equal(march(13)) || !f.P95.Equal(march(18)) {
+		t.Errorf("P50, P85, P95 = %s, %s, %s, want 2024-03-08, 2024-03-13, 2024-03-18",
+			f.P50.Format(time.DateOnly), f.P85.Format(time.DateOnly), f.P95.Format(time.DateOnly))
+	}
+	// нужно три удачных дня из пяти рабочих: вероятность 1/2, точное значение зависит от зерна
+	if f.Probability != 0.502 {
+		t.Errorf("Probability = %v, want 0.502", f.Probability)
+	}
+
+	again := ForecastCompletion(sprint, history, forecastNow, opts)
+	if *again != *f {
+		t.Errorf("forecast with the same seed = %+v, want %+v", *again, *f)
+	}
+}
+
+func TestForecastWithoutThroughput(t *testing.T) {
+	if f := ForecastCompletion(currentSprint(3, march(15)), nil, forecastNow, ForecastOptions{Seed: 1}); f != nil {
+		t.Errorf("ForecastCompletion without history = %+v, want nil", f)
+	}
+	history := []domain.Sprint{pastSprint(0, 0, 0, 0, 0, 0, 0, 0, 0, 0)}
+	if f := ForecastCompletion(currentSprint(3, march(15)), history, forecastNow, ForecastOptions{Seed: 1}); f != nil {
+		t.Errorf("ForecastCompletion without closed items = %+v, want nil", f)
+	}
+}
+
+func TestForecastNothingRemaining(t *testing.T) {
+	f := ForecastCompletion(currentSprint(0, march(15)), nil, forecastNow, ForecastOptions{Seed: 1})
+	if f == nil || f.Probability != 1 || !f.P95.Equal(march(4)) {
+		t.Errorf("ForecastCompletion = %+v, want probability 1 and today", f)
+	}
+}
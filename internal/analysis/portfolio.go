@@ -0,0 +1,195 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// RiskLevel — уровень риска команды в портфельном обзоре.
+type RiskLevel string
+
+const (
+	RiskLow    RiskLevel = "low"
+	RiskMedium RiskLevel = "medium"
+	RiskHigh   RiskLevel = "high"
+)
+
+// Веса составляющих оценки риска; в сумме 100.
+const (
+	riskWeightSchedule = 40
+	riskWeightForecast = 20
+	riskWeightWIP      = 15
+	riskWeightBuilds   = 15
+	riskWeightAging    = 10
+)
+
+// riskSaturation — сколько нарушений WIP или стареющих элементов дают максимальный вклад.
+const riskSaturation = 5
+
+// PortfolioInput — результаты анализа одной команды, из которых строится строка портфеля.
+type PortfolioInput struct {
+	Team     string
+	Sprint   *domain.Sprint
+	WIP      *WIPReport
+	Aging    *AgingWIP
+	Builds   []BuildHealth
+	Forecast *Forecast
+}
+
+// PortfolioTeam — строка портфельного обзора: одна команда.
+type PortfolioTeam struct {
+	Team   string `json:"team"`
+	Sprint string `json:"sprint,omitempty"`
+	// Progress — доля закрытых story points спринта (доля элементов, если ничего не оценено).
+	Progress float64 `json:"progress"`
+	// Elapsed — доля прошедших рабочих дней спринта.
+	Elapsed       float64 `json:"elapsed"`
+	ItemsDone     int     `json:"itemsDone"`
+	ItemsTotal    int     `json:"itemsTotal"`
+	WIPViolations int     `json:"wipViolations"`
+	AgingItems    int     `json:"agingItems"`
+	BuildsRed     int     `json:"buildsRed"`
+	BuildsTotal   int     `json:"buildsTotal"`
+	// BuildSuccessRate — средняя доля успешных сборок по конфигурациям, в которых были сборки.
+	BuildSuccessRate float64 `json:"buildSuccessRate"`
+	// ForecastProbability — вероятность закрыть спринт в срок, если есть прогноз.
+	ForecastProbability *float64 `json:"forecastProbability,omitempty"`
+	// RiskScore — оценка риска от 0 до 100: отставание от графика, прогноз,
+	// нарушения WIP, красные сборки и стареющие элементы.
+	RiskScore int       `json:"riskScore"`
+	Risk      RiskLevel `json:"risk"`
+	// Error — почему данные команды не удалось собрать; остальные поля тогда пустые.
+	Error string `json:"error,omitempty"`
+}
+
+// AnalyzePortfolioTeam сводит анализ команды в одну строку портфеля и считает оценку риска.
+func AnalyzePortfolioTeam(in PortfolioInput, now time.Time) PortfolioTeam {
+	row := PortfolioTeam{Team: in.Team}
+
+	if in.Sprint != nil {
+		row.Sprint = in.Sprint.Name
+		row.Progress, row.ItemsDone, row.ItemsTotal = sprintProgress(in.Sprint)
+		row.Elapsed = sprintElapsed(in.Sprint, now)
+	}
+	if in.WIP != nil {
+		row.WIPViolations = len(in.WIP.Warnings)
+	}
+	if in.Aging != nil {
+		row.AgingItems = len(in.Aging.Items)
+	}
+
+	withBuilds := 0
+	for _, h := range in.Builds {
+		row.BuildsTotal++
+		if h.IsRed() {
+			row.BuildsRed++
+		}
+		if h.Builds > 0 {
+			row.BuildSuccessRate += h.SuccessRate
+			withBuilds++
+		}
+	}
+	if withBuilds > 0 {
+		row.BuildSuccessRate /= float64(withBuilds)
+	}
+
+	if in.Forecast != nil && in.Forecast.EndDate != nil {
+		p := in.Forecast.Probability
+		row.ForecastProbability = &p
+	}
+
+	row.RiskScore = riskScore(row)
+	row.Risk = riskLevel(row.RiskScore)
+	return row
+}
+
+// PortfolioError — строка портфеля для команды, данные которой собрать не удалось.
+// Такая команда считается рискованной: о ней ничего не известно.
+func PortfolioError(team string, err error) PortfolioTeam {
+	return PortfolioTeam{Team: team, Error: err.Error(), RiskScore: 100, Risk: RiskHigh}
+}
+
+// sprintProgress считает долю закрытого по элементам бэклога спринта.
+func sprintProgress(sprint *domain.Sprint) (progress float64, done, total int) {
+	var donePoints, totalPoints float64
+	for _, wi := range sprint.WorkItems {
+		if !isBacklogItem(wi) || wi.StateCategory == domain.StateRemoved {
+			continue
+		}
+		total++
+		totalPoints += wi.StoryPoints
+		if wi.StateCategory == domain.StateCompleted {
+			done++
+			donePoints += wi.StoryPoints
+		}
+	}
+
+	switch {
+	case totalPoints > 0:
+		progress = donePoints / totalPoints
+	case total > 0:
+		progress = float64(done) / float64(total)
+	}
+	return progress, done, total
+}
+
+// sprintElapsed — доля рабочих дней спринта, прошедших к now (сегодняшний день не считается прошедшим).
+func sprintElapsed(sprint *domain.Sprint, now time.Time) float64 {
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return 0
+	}
+
+	loc := now.Location()
+	start := startOfDay(sprint.StartDate.In(loc))
+	end := startOfDay(sprint.EndDate.In(loc))
+	today := startOfDay(now)
+	total, passed := 0, 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if !isWorkingDay(day) {
+			continue
+		}
+		total++
+		if day.Before(today) {
+			passed++
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+	return float64(passed) / float64(total)
+}
+
+// riskScore складывает взвешенные составляющие риска. Отставание — насколько доля
+// прошедшего времени опережает долю сделанного; без прогноза его вес достаётся отставанию.
+func riskScore(row PortfolioTeam) int {
+	behind := math.Max(0, row.Elapsed-row.Progress)
+
+	score := 0.0
+	if row.ForecastProbability != nil {
+		score += riskWeightSchedule * behind
+		score += riskWeightForecast * (1 - *row.ForecastProbability)
+	} else {
+		score += (riskWeightSchedule + riskWeightForecast) * behind
+	}
+	score += riskWeightWIP * math.Min(1, float64(row.WIPViolations)/riskSaturation)
+	score += riskWeightAging * math.Min(1, float64(row.AgingItems)/riskSaturation)
+	if row.BuildsTotal > 0 {
+		score += riskWeightBuilds * float64(row.BuildsRed) / float64(row.BuildsTotal)
+	}
+
+	return int(math.Round(math.Min(100, score)))
+}
+
+func riskLevel(score int) RiskLevel {
+	switch {
+	case score >= 60:
+		return RiskHigh
+	case score >= 30:
+		return RiskMedium
+	default:
+		return RiskLow
+	}
+}
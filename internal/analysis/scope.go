@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// ScopeItem — элемент, добавленный в спринт или убранный из него после начала.
+type ScopeItem struct {
+	ID          int                 `json:"id"`
+	Name        string              `json:"name"`
+	Type        domain.WorkItemType `json:"type"`
+	URL         string              `json:"url,omitempty"`
+	StoryPoints float64             `json:"storyPoints"`
+	// At и By — когда и кем элемент добавлен в спринт (известно только для добавленных).
+	At *time.Time `json:"at,omitempty"`
+	By string     `json:"by,omitempty"`
+}
+
+// ScopeChange — изменение объёма спринта относительно обязательств на старте.
+type ScopeChange struct {
+	CommittedAt     time.Time   `json:"committedAt"`
+	CommittedItems  int         `json:"committedItems"`
+	CommittedPoints float64     `json:"committedPoints"`
+	Added           []ScopeItem `json:"added"`
+	Removed         []ScopeItem `json:"removed"`
+	AddedPoints     float64     `json:"addedPoints"`
+	RemovedPoints   float64     `json:"removedPoints"`
+	// UnplannedPercent — доля добавленного после старта в текущем объёме спринта:
+	// по story points, а если их нет — по количеству элементов.
+	UnplannedPercent float64 `json:"unplannedPercent"`
+}
+
+// AnalyzeScope сравнивает элементы бэклога спринта со снимком обязательств committed.
+// Удалёнными считаются элементы, которые ушли из спринта или перешли в категорию Removed.
+func AnalyzeScope(committed *domain.Sprint, committedAt time.Time, current *domain.Sprint) *ScopeChange {
+	if committed == nil || current == nil {
+		return nil
+	}
+
+	res := &ScopeChange{CommittedAt: committedAt, Added: []ScopeItem{}, Removed: []ScopeItem{}}
+
+	planned := map[int]bool{}
+	for _, wi := range committed.WorkItems {
+		if !isBacklogItem(wi) || wi.StateCategory == domain.StateRemoved {
+			continue
+		}
+		planned[wi.ID] = true
+		res.CommittedItems++
+		res.CommittedPoints += wi.StoryPoints
+	}
+
+	scopeItems, scopePoints := 0, 0.0
+	present := map[int]bool{}
+	for _, wi := range current.WorkItems {
+		if !isBacklogItem(wi) {
+			continue
+		}
+		if wi.StateCategory == domain.StateRemoved {
+			if planned[wi.ID] {
+				res.Removed = append(res.Removed, newScopeItem(wi))
+				res.RemovedPoints += wi.StoryPoints
+			}
+			present[wi.ID] = true
+			continue
+		}
+
+		present[wi.ID] = true
+		scopeItems++
+		scopePoints += wi.StoryPoints
+
+		if !planned[wi.ID] {
+			item := newScopeItem(wi)
+			item.At, item.By = wi.AddedToSprintAt, wi.AddedToSprintBy
+			res.Added = append(res.Added, item)
+			res.AddedPoints += wi.StoryPoints
+		}
+	}
+
+	for _, wi := range committed.WorkItems {
+		if planned[wi.ID] && !present[wi.ID] {
+			res.Removed = append(res.Removed, newScopeItem(wi))
+			res.RemovedPoints += wi.StoryPoints
+		}
+	}
+
+	switch {
+	case scopePoints > 0:
+		res.UnplannedPercent = res.AddedPoints / scopePoints * 100
+	case scopeItems > 0:
+		res.UnplannedPercent = float64(len(res.Added)) / float64(scopeItems) * 100
+	}
+
+	sort.Slice(res.Removed, func(i, j int) bool { return res.Removed[i].ID < res.Removed[j].ID })
+
+	return res
+}
+
+func newScopeItem(wi domain.WorkItem) ScopeItem {
+	return ScopeItem{ID: wi.ID, Name: wi.Name, Type: wi.Type, URL: wi.URL, StoryPoints: wi.StoryPoints}
+}
@@ -0,0 +1,197 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// Источники обязательств спринта (SprintVelocity.CommitmentSource).
+const (
+	// CommitmentSnapshot — сохранённый снимок обязательств, сделанный в начале спринта.
+	CommitmentSnapshot = "snapshot"
+	// CommitmentRevisions — состав итерации к концу первого дня спринта по истории ревизий.
+	CommitmentRevisions = "revisions"
+	// CommitmentSprintEnd — состав спринта на конец: ни снимка, ни истории нет,
+	// перенесённая в следующий спринт работа в обещанное не попадает.
+	CommitmentSprintEnd = "sprintEnd"
+)
+
+// SprintVelocity — обещанное и сделанное за один спринт.
+type SprintVelocity struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	StartDate       *time.Time `json:"startDate,omitempty"`
+	EndDate         *time.Time `json:"endDate,omitempty"`
+	CommittedPoints float64    `json:"committedPoints"`
+	CompletedPoints float64    `json:"completedPoints"`
+	CommittedItems  int        `json:"committedItems"`
+	CompletedItems  int        `json:"completedItems"`
+	// CommitmentSource — откуда взят состав обязательств: snapshot, revisions или sprintEnd.
+	CommitmentSource string `json:"commitmentSource"`
+	// SayDo — доля сделанного в спринте из обещанного в его начале: по story points,
+	// а если их нет — по количеству.
+	SayDo float64 `json:"sayDo"`
+}
+
+// Velocity — скорость команды за последние спринты.
+type Velocity struct {
+	Sprints       []SprintVelocity `json:"sprints"`
+	AveragePoints float64          `json:"averagePoints"`
+	StdDevPoints  float64          `json:"stdDevPoints"`
+	AverageItems  float64          `json:"averageItems"`
+	StdDevItems   float64          `json:"stdDevItems"`
+	AverageSayDo  float64          `json:"averageSayDo"`
+	// Trend — наклон линии тренда сделанных story points, в пунктах за спринт.
+	Trend float64 `json:"trend"`
+}
+
+// AnalyzeVelocity считает скорость по завершённым спринтам. Сделанным считаются элементы
+// спринта в категории Completed. Обещанное — состав спринта в его начале: из снимка обязательств
+// commitments (по ID спринта), иначе из истории ревизий (Sprint.CommittedIDs), иначе состав на конец.
+// Элементы, перенесённые из спринта, ищутся в остальных спринтах истории и в current.
+// Учитываются только элементы бэклога (истории и баги).
+func AnalyzeVelocity(history []domain.Sprint, current *domain.Sprint, commitments map[string]*domain.Sprint) *Velocity {
+	if len(history) == 0 {
+		return nil
+	}
+
+	known := map[int]domain.WorkItem{}
+	for _, sprint := range history {
+		for _, wi := range sprint.WorkItems {
+			known[wi.ID] = wi
+		}
+	}
+	if current != nil {
+		for _, wi := range current.WorkItems {
+			known[wi.ID] = wi
+		}
+	}
+
+	v := &Velocity{Sprints: make([]SprintVelocity, 0, len(history))}
+
+	points := make([]float64, 0, len(history))
+	items := make([]float64, 0, len(history))
+	sayDo := make([]float64, 0, len(history))
+
+	for _, sprint := range history {
+		sv := SprintVelocity{
+			ID:        sprint.ID,
+			Name:      sprint.Name,
+			StartDate: sprint.StartDate,
+			EndDate:   sprint.EndDate,
+		}
+
+		done := map[int]bool{}
+		for _, wi := range sprint.WorkItems {
+			if isBacklogItem(wi) && wi.StateCategory == domain.StateCompleted {
+				sv.CompletedPoints += wi.StoryPoints
+				sv.CompletedItems++
+				done[wi.ID] = true
+			}
+		}
+
+		committed, source := sprintCommitment(sprint, commitments[sprint.ID], known)
+		sv.CommitmentSource = source
+
+		var donePoints float64
+		var doneItems int
+		for _, wi := range committed {
+			sv.CommittedPoints += wi.StoryPoints
+			sv.CommittedItems++
+			if done[wi.ID] {
+				donePoints += wi.StoryPoints
+				doneItems++
+			}
+		}
+
+		switch {
+		case sv.CommittedPoints > 0:
+			sv.SayDo = donePoints / sv.CommittedPoints
+		case sv.CommittedItems > 0:
+			sv.SayDo = float64(doneItems) / float64(sv.CommittedItems)
+		}
+
+		v.Sprints = append(v.Sprints, sv)
+		points = append(points, sv.CompletedPoints)
+		items = append(items, float64(sv.CompletedItems))
+		sayDo = append(sayDo, sv.SayDo)
+	}
+
+	v.AveragePoints, v.StdDevPoints = mean(points), stdDev(points)
+	v.AverageItems, v.StdDevItems = mean(items), stdDev(items)
+	v.AverageSayDo = mean(sayDo)
+	v.Trend = slope(points)
+
+	return v
+}
+
+// sprintCommitment возвращает элементы бэклога, обещанные в начале спринта, и источник состава.
+// Оценки берутся из снимка, если он есть, иначе — текущие.
+func sprintCommitment(sprint domain.Sprint, snapshot *domain.Sprint,
+	known map[int]domain.WorkItem) ([]domain.WorkItem, string) {
+	committed := make([]domain.WorkItem, 0)
+	add := func(wi domain.WorkItem) {
+		if isBacklogItem(wi) && wi.StateCategory != domain.StateRemoved {
+			committed = append(committed, wi)
+		}
+	}
+
+	switch {
+	case snapshot != nil:
+		for _, wi := range snapshot.WorkItems {
+			add(wi)
+		}
+		return committed, CommitmentSnapshot
+	case sprint.CommittedIDs != nil:
+		for _, id := range sprint.CommittedIDs {
+			if wi, ok := known[id]; ok {
+				add(wi)
+			}
+		}
+		return committed, CommitmentRevisions
+	default:
+		for _, wi := range sprint.WorkItems {
+			add(wi)
+		}
+		return committed, CommitmentSprintEnd
+	}
+}
+
+// isBacklogItem — элемент бэклога, по которому считается скорость.
+func isBacklogItem(wi domain.WorkItem) bool {
+	return wi.Type == domain.WorkItemStory || wi.Type == domain.WorkItemBug
+}
+
+// stdDev — выборочное стандартное отклонение.
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	m := mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// slope — наклон линейной регрессии значений по их порядковому номеру.
+func slope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
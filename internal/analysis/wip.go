@@ -0,0 +1,131 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"scrum-eye/internal/config"
+	"scrum-eye/internal/domain"
+)
+
+// Unassigned — имя, под которым учитываются элементы без исполнителя.
+const Unassigned = "(unassigned)"
+
+// PersonLoad — загрузка одного участника команды.
+type PersonLoad struct {
+	Name            string  `json:"name"`
+	InProgress      int     `json:"inProgress"`
+	OpenStoryPoints float64 `json:"openStoryPoints"`
+}
+
+// WIPReport — результат проверки лимитов незавершённой работы.
+type WIPReport struct {
+	TeamInProgress      int          `json:"teamInProgress"`
+	WipLimit            int          `json:"wipLimit"`
+	WipPerPerson        int          `json:"wipPerPerson"`
+	OverloadStoryPoints float64      `json:"overloadStoryPoints"`
+	People              []PersonLoad `json:"people"`
+	Warnings            []string     `json:"warnings"`
+}
+
+// AnalyzeWIP считает элементы в работе по команде и по исполнителям
+// и сравнивает их с лимитами из секции metrics. Нулевой лимит не проверяется.
+// Какие элементы считать, задаёт metrics.wipLevel; по умолчанию дочерняя задача
+// истории в работе не увеличивает WIP команды, а исполнителю засчитывается,
+// только если историю ведёт кто-то другой.
+func AnalyzeWIP(sprint *domain.Sprint, metrics config.MetricsConfig) *WIPReport {
+	res := &WIPReport{
+		WipLimit:            metrics.WipLimit,
+		WipPerPerson:        metrics.WipPerPerson,
+		OverloadStoryPoints: metrics.OverloadStoryPoints,
+	}
+	if sprint == nil {
+		return res
+	}
+
+	people := map[string]*PersonLoad{}
+	person := func(name string) *PersonLoad {
+		if name == "" {
+			name = Unassigned
+		}
+		p, ok := people[name]
+		if !ok {
+			p = &PersonLoad{Name: name}
+			people[name] = p
+		}
+		return p
+	}
+
+	counted := func(wi domain.WorkItem) bool {
+		if !isWorkItemLevel(wi) || !isOpen(wi) {
+			return false
+		}
+		switch metrics.WipLevel {
+		case config.WipLevelBacklog:
+			return isBacklogItem(wi)
+		case config.WipLevelTasks:
+			return wi.Type == domain.WorkItemTask
+		}
+		return true
+	}
+
+	// родители в работе: их дочерние задачи — та же работа, и второй раз она не считается
+	inProgress := map[int]domain.WorkItem{}
+	for _, wi := range sprint.WorkItems {
+		if counted(wi) && isInProgress(wi) {
+			inProgress[wi.ID] = wi
+		}
+	}
+
+	for _, wi := range sprint.WorkItems {
+		if !counted(wi) {
+			continue
+		}
+
+		p := person(wi.AssignedTo)
+		p.OpenStoryPoints += wi.StoryPoints
+
+		if !isInProgress(wi) {
+			continue
+		}
+		parent, parentCounted := inProgress[wi.ParentID]
+		if !parentCounted {
+			res.TeamInProgress++
+		}
+		if !parentCounted || parent.AssignedTo != wi.AssignedTo {
+			p.InProgress++
+		}
+	}
+
+	res.People = make([]PersonLoad, 0, len(people))
+	for _, p := range people {
+		res.People = append(res.People, *p)
+	}
+	sort.Slice(res.People, func(i, j int) bool {
+		if res.People[i].InProgress != res.People[j].InProgress {
+			return res.People[i].InProgress > res.People[j].InProgress
+		}
+		return res.People[i].Name < res.People[j].Name
+	})
+
+	if metrics.WipLimit > 0 && res.TeamInProgress > metrics.WipLimit {
+		res.Warnings = append(res.Warnings, fmt.Sprintf(
+			"team WIP limit exceeded: %d items in progress, limit %d", res.TeamInProgress, metrics.WipLimit))
+	}
+
+	for _, p := range res.People {
+		if p.Name == Unassigned {
+			continue
+		}
+		if metrics.WipPerPerson > 0 && p.InProgress > metrics.WipPerPerson {
+			res.Warnings = append(res.Warnings, fmt.Sprintf(
+				"%s has %d items in progress, limit %d", p.Name, p.InProgress, metrics.WipPerPerson))
+		}
+		if metrics.OverloadStoryPoints > 0 && p.OpenStoryPoints > metrics.OverloadStoryPoints {
+			res.Warnings = append(res.Warnings, fmt.Sprintf(
+				"%s is overloaded: %g open story points, limit %g", p.Name, p.OpenStoryPoints, metrics.OverloadStoryPoints))
+		}
+	}
+
+	return res
+}
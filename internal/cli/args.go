@@ -2,52 +2,159 @@ package cli
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
 )
 
-func parseArgs(args []string) (teamName string, customPath string, err error) {
-	for _, a := range args {
-		if strings.HasPrefix(a, "--path=") {
-			customPath = strings.TrimPrefix(a, "--path=")
-			continue
-		}
+// commonOptions — флаги, общие для команд, которые читают конфиги.
+type commonOptions struct {
+	customPath string
+	// yes — автоматически соглашаться на создание конфигов; noInput — никогда ничего не спрашивать.
+	yes     bool
+	noInput bool
+}
 
-		// первый не-флаг — это имя команды
-		if !strings.HasPrefix(a, "-") && teamName == "" {
-			teamName = a
-			continue
-		}
+// options — флаги команды report.
+type options struct {
+	commonOptions
+	teamName     string
+	format       string
+	output       string
+	sprint       string
+	sprintOffset int
+	cfdExport    string
+	// all, group и concurrency — портфельный обзор нескольких команд вместо отчёта одной.
+	all         bool
+	group       string
+	concurrency int
+}
+
+// newFlagSet создаёт набор флагов команды с собственной справкой (--help).
+func newFlagSet(cmd command) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Использование:\n  scrum-eye %s %s\n\n%s\n", cmd.name, cmd.args, cmd.summary)
 
-		if a == "--path" || a == "-path" {
-			return "", "", errors.New("формат --path без значения не поддерживается, используй --path=<путь>")
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(out, "\nФлаги:")
+			fs.PrintDefaults()
 		}
+	}
+	return fs
+}
+
+func addPathFlag(fs *flag.FlagSet, opts *commonOptions) {
+	fs.StringVar(&opts.customPath, "path", "", "папка с конфигами (по умолчанию $HOME/.scrum-eye)")
+}
 
-		// всё остальное считаем лишними аргументами
-		if !strings.HasPrefix(a, "--") {
-			return "", "", fmt.Errorf("лишний аргумент: %s", a)
+func addCommonFlags(fs *flag.FlagSet, opts *commonOptions) {
+	addPathFlag(fs, opts)
+	fs.BoolVar(&opts.yes, "yes", false, "создавать недостающие конфиги без вопросов")
+	fs.BoolVar(&opts.yes, "y", false, "то же, что --yes")
+	fs.BoolVar(&opts.noInput, "no-input", false, "никогда не задавать вопросов, даже в терминале")
+}
+
+// parseFlags разбирает флаги вперемешку с позиционными аргументами
+// (scrum-eye report my-team --format=json) и возвращает позиционные.
+// Поддерживаются обе формы: --path x и --path=x. Всё после «--» считается
+// позиционными аргументами, даже если похоже на флаг.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := args[:len(args)-len(rest)]; endsWithTerminator(fs, consumed) {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
 		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// endsWithTerminator сообщает, что flag.Parse остановился на «--», а не взял его
+// значением флага (--path --).
+func endsWithTerminator(fs *flag.FlagSet, consumed []string) bool {
+	n := len(consumed)
+	if n == 0 || consumed[n-1] != "--" {
+		return false
+	}
+	if n == 1 {
+		return true
 	}
 
-	return teamName, customPath, nil
+	prev := consumed[n-2]
+	name := strings.TrimLeft(prev, "-")
+	if !strings.HasPrefix(prev, "-") || prev == "--" || strings.Contains(name, "=") {
+		return true
+	}
+	f := fs.Lookup(name)
+	if f == nil {
+		return true
+	}
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return true
+	}
+	return false
+}
+
+// expectArgs проверяет количество позиционных аргументов команды.
+func expectArgs(cmd command, positional []string, minArgs, maxArgs int) error {
+	if len(positional) < minArgs {
+		return usageError(fmt.Errorf("%s: не хватает аргументов, нужно: %s", cmd.name, cmd.args))
+	}
+	if len(positional) > maxArgs {
+		return usageError(fmt.Errorf("%s: лишний аргумент: %s", cmd.name, positional[maxArgs]))
+	}
+	return nil
+}
+
+// flagError переводит ошибку разбора флагов в код выхода. --help ошибкой не считается.
+func flagError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return usageError(err)
+}
+
+func usageError(err error) error {
+	return &ExitError{Code: ExitUsage, Err: err}
 }
 
 func printUsage() {
 	fmt.Println("Использование:")
-	fmt.Println("  scrum-eye.exe <team-name> [--path=<путь к папке с конфигами>]")
+	fmt.Println("  scrum-eye <команда> [аргументы] [флаги]")
+	fmt.Println("  scrum-eye <team-name> [флаги]          то же, что scrum-eye report <team-name>")
+	fmt.Println()
+	fmt.Println("Команды:")
+	for _, cmd := range commands() {
+		usage := strings.TrimSpace(cmd.name + " " + cmd.args)
+		fmt.Printf("  %-26s %s\n", usage, strings.SplitN(cmd.summary, "\n", 2)[0])
+	}
+	fmt.Println()
+	fmt.Println("Справка по команде: scrum-eye <команда> --help")
 	fmt.Println()
 	fmt.Println("По умолчанию конфиги ищутся в:")
 	fmt.Println("  $HOME/.scrum-eye/global.yaml")
 	fmt.Println("  $HOME/.scrum-eye/teams/<team-name>.yaml")
 	fmt.Println()
 	fmt.Println("Примеры:")
-	fmt.Println("  scrum-eye.exe my-team")
-	fmt.Println("  scrum-eye.exe my-team --path=C:\\configs\\scrum-eye")
-
-	// маленький бонус: если хочется подсказать HOME:
-	home, err := os.UserHomeDir()
-	if err == nil {
-		fmt.Printf("\nТекущая домашняя директория: %s\n", home)
-	}
+	fmt.Println("  scrum-eye init my-team")
+	fmt.Println("  scrum-eye report my-team")
+	fmt.Println("  scrum-eye report my-team --path C:\\configs\\scrum-eye")
+	fmt.Println("  scrum-eye report my-team --format=json | jq .project.currentSprint.name")
+	fmt.Println("  scrum-eye report my-team --sprint=previous")
+	fmt.Println("  scrum-eye history my-team")
+	fmt.Println("  scrum-eye diff my-team --from=2024-05-01")
+	fmt.Println("  source <(scrum-eye completion bash)")
 }
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		positional []string
+		path       string
+		format     string
+		yes        bool
+	}{
+		{
+			name:       "separate value",
+			args:       []string{"--path", "x", "core"},
+			positional: []string{"core"},
+			path:       "x",
+		},
+		{
+			name:       "value after equals",
+			args:       []string{"--path=x", "core"},
+			positional: []string{"core"},
+			path:       "x",
+		},
+		{
+			name:       "flags after positional arguments",
+			args:       []string{"core", "--format=json", "extra", "-y", "--path", "x"},
+			positional: []string{"core", "extra"},
+			path:       "x",
+			format:     "json",
+			yes:        true,
+		},
+		{
+			name:       "everything after the terminator is positional",
+			args:       []string{"--path", "x", "--", "--format=json", "-y"},
+			positional: []string{"--format=json", "-y"},
+			path:       "x",
+		},
+		{
+			name:       "terminator after a positional argument",
+			args:       []string{"core", "-y", "--", "--path", "y", "--"},
+			positional: []string{"core", "--path", "y", "--"},
+			yes:        true,
+		},
+		{
+			name:       "terminator after a bool flag",
+			args:       []string{"-y", "--", "-dash-team"},
+			positional: []string{"-dash-team"},
+			yes:        true,
+		},
+		{
+			name:       "terminator as a flag value",
+			args:       []string{"--path", "--", "core", "--format", "json"},
+			positional: []string{"core"},
+			path:       "--",
+			format:     "json",
+		},
+		{
+			name:       "no arguments",
+			args:       []string{},
+			positional: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("report", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			opts := &options{}
+			addCommonFlags(fs, &opts.commonOptions)
+			fs.StringVar(&opts.format, "format", "", "")
+
+			positional, err := parseFlags(fs, tt.args)
+			if err != nil {
+				t.Fatalf("parseFlags: %v", err)
+			}
+			if !reflect.DeepEqual(positional, tt.positional) {
+				t.Errorf("positional = %q, want %q", positional, tt.positional)
+			}
+			if opts.customPath != tt.path || opts.format != tt.format || opts.yes != tt.yes {
+				t.Errorf("path, format, yes = %q, %q, %v, want %q, %q, %v",
+					opts.customPath, opts.format, opts.yes, tt.path, tt.format, tt.yes)
+			}
+		})
+	}
+}
+
+func TestParseFlagsUnknownFlag(t *testing.T) {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	addCommonFlags(fs, &commonOptions{})
+
+	if _, err := parseFlags(fs, []string{"core", "--formt=json"}); err == nil {
+		t.Error("parseFlags succeeded, want an unknown flag error")
+	}
+	if _, err := parseFlags(fs, []string{"--help"}); err != flag.ErrHelp {
+		t.Errorf("parseFlags(--help) = %v, want flag.ErrHelp", err)
+	}
+}
@@ -0,0 +1,335 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"scrum-eye/internal/collector"
+	"scrum-eye/internal/config"
+)
+
+// command — подкоманда CLI.
+type command struct {
+	name    string
+	args    string
+	summary string
+	// setup регистрирует флаги команды и возвращает функцию запуска,
+	// которая получает позиционные аргументы.
+	setup func(fs *flag.FlagSet) func(positional []string) error
+}
+
+func commands() []command {
+	return []command{
+		reportCommand(),
+		initCommand(),
+		validateCommand(),
+		teamsCommand(),
+		historyCommand(),
+		diffCommand(),
+		serveCommand(),
+		completionCommand(),
+	}
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// Run разбирает командную строку и запускает подкоманду.
+// Вызов без подкоманды (scrum-eye <team> [флаги]) работает как report.
+func Run(args []string) error {
+	if len(args) == 0 {
+		printUsage()
+		return usageError(errors.New("не указана команда"))
+	}
+
+	switch args[0] {
+	case "-h", "--help", "help":
+		if len(args) > 1 {
+			if cmd, ok := findCommand(args[1]); ok {
+				return runCommand(cmd, []string{"--help"})
+			}
+		}
+		printUsage()
+		return nil
+	}
+
+	if cmd, ok := findCommand(args[0]); ok {
+		return runCommand(cmd, args[1:])
+	}
+
+	cmd, _ := findCommand("report")
+	return runCommand(cmd, args)
+}
+
+func runCommand(cmd command, args []string) error {
+	fs := newFlagSet(cmd)
+	run := cmd.setup(fs)
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return flagError(err)
+	}
+	return run(positional)
+}
+
+func reportCommand() command {
+	cmd := command{
+		name: "report",
+		args: "<team>",
+		summary: "Собирает данные команды и выводит отчёт по спринту.\n" +
+			"С --all или --group собирает несколько команд параллельно и выводит портфельный обзор.",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		opts := &options{}
+		addCommonFlags(fs, &opts.commonOptions)
+		fs.StringVar(&opts.format, "format", "console", "формат: console, json, ndjson, markdown, html")
+		fs.StringVar(&opts.output, "output", "", "файл отчёта (HTML по умолчанию сохраняется в <storage.path>/<team>/reports)")
+		fs.StringVar(&opts.sprint, "sprint", "", "спринт: current, previous, next, имя или путь итерации")
+		fs.IntVar(&opts.sprintOffset, "sprint-offset", 0, "смещение от текущего спринта, например -2")
+		fs.StringVar(&opts.cfdExport, "cfd-export", "", "сохранить данные CFD в файл .csv или .json")
+		fs.BoolVar(&opts.all, "all", false, "портфельный обзор всех команд из папки teams")
+		fs.StringVar(&opts.group, "group", "", "портфельный обзор команд группы из portfolio.groups в global.yaml")
+		fs.IntVar(&opts.concurrency, "concurrency", 0,
+			fmt.Sprintf("сколько команд собирать одновременно (по умолчанию portfolio.concurrency или %d)", DefaultConcurrency))
+
+		return func(positional []string) error {
+			if opts.all || opts.group != "" {
+				if opts.all && opts.group != "" {
+					return usageError(errors.New("report: --all и --group нельзя указывать вместе"))
+				}
+				if opts.cfdExport != "" {
+					return usageError(errors.New("report: --cfd-export работает только для одной команды"))
+				}
+				if err := expectArgs(cmd, positional, 0, 0); err != nil {
+					return err
+				}
+				return runPortfolio(*opts)
+			}
+			if err := expectArgs(cmd, positional, 1, 1); err != nil {
+				return err
+			}
+			opts.teamName = positional[0]
+			return runReport(*opts)
+		}
+	}
+	return cmd
+}
+
+func initCommand() command {
+	cmd := command{
+		name:    "init",
+		args:    "<team>",
+		summary: "Создаёт папку с конфигами и шаблоны global.yaml и конфига команды, если их ещё нет.",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		var common commonOptions
+		addPathFlag(fs, &common)
+
+		return func(positional []string) error {
+			if err := expectArgs(cmd, positional, 1, 1); err != nil {
+				return err
+			}
+			return runInit(common, positional[0])
+		}
+	}
+	return cmd
+}
+
+func validateCommand() command {
+	cmd := command{
+		name:    "validate",
+		args:    "[team]",
+		summary: "Проверяет конфиги: одной команды или всех команд из папки teams.",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		var common commonOptions
+		addPathFlag(fs, &common)
+
+		return func(positional []string) error {
+			if err := expectArgs(cmd, positional, 0, 1); err != nil {
+				return err
+			}
+			team := ""
+			if len(positional) > 0 {
+				team = positional[0]
+			}
+			return runValidate(common, team)
+		}
+	}
+	return cmd
+}
+
+func teamsCommand() command {
+	cmd := command{
+		name:    "teams",
+		args:    "list",
+		summary: "Выводит имена настроенных команд, по одному на строку.",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		var common commonOptions
+		addPathFlag(fs, &common)
+
+		return func(positional []string) error {
+			if err := expectArgs(cmd, positional, 0, 1); err != nil {
+				return err
+			}
+			if len(positional) > 0 && positional[0] != "list" {
+				return usageError(fmt.Errorf("teams: неизвестная подкоманда %q", positional[0]))
+			}
+			return runTeamsList(common)
+		}
+	}
+	return cmd
+}
+
+// runInit создаёт недостающие конфиги без вопросов: init для того и вызывают.
+func runInit(common commonOptions, teamName string) error {
+	paths, err := resolveConfigPaths(teamName, common.customPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(paths.TeamsDir, 0o755); err != nil {
+		return fmt.Errorf("не удалось создать директорию %s: %w", paths.TeamsDir, err)
+	}
+
+	if err := initFile(paths.GlobalPath, "global.yaml", func() error {
+		return writeGlobalTemplate(paths.GlobalPath)
+	}); err != nil {
+		return err
+	}
+	if err := initFile(paths.TeamFile, "конфиг команды", func() error {
+		return writeTeamTemplate(paths.TeamFile, teamName)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Заполни шаблоны и проверь их: scrum-eye validate", teamName)
+	return nil
+}
+
+func initFile(path, title string, write func() error) error {
+	if _, err := os.Stat(path); err == nil {
+		fmt.Printf("✔ %s уже есть: %s\n", title, path)
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := write(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Создан %s: %s\n", title, path)
+	return nil
+}
+
+// runValidate загружает конфиги и проверяет их так же, как это делает report, но без запросов к API.
+func runValidate(common commonOptions, teamName string) error {
+	paths, err := resolveConfigPaths(teamName, common.customPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(paths.GlobalPath); err != nil {
+		return &ExitError{Code: ExitConfigMissing, Err: fmt.Errorf("global.yaml не найден (%s): создай его через scrum-eye init", paths.GlobalPath)}
+	}
+
+	teams := []string{teamName}
+	if teamName == "" {
+		teams, err = listTeams(paths.TeamsDir)
+		if err != nil {
+			return err
+		}
+		if len(teams) == 0 {
+			return &ExitError{Code: ExitConfigMissing, Err: fmt.Errorf("в %s нет ни одного конфига команды", paths.TeamsDir)}
+		}
+	}
+
+	failed := 0
+	for _, team := range teams {
+		warnings, err := validateTeam(paths, team)
+		if err != nil {
+			failed++
+			var invalid *config.ValidationError
+			if errors.As(err, &invalid) {
+				fmt.Printf("❌ %s\n", team)
+				for _, issue := range invalid.Issues {
+					fmt.Printf("   %s\n", issue)
+				}
+				printValidateWarnings(invalid.Warnings)
+				continue
+			}
+			fmt.Printf("❌ %s: %v\n", team, err)
+			continue
+		}
+		fmt.Printf("✅ %s\n", team)
+		printValidateWarnings(warnings)
+	}
+
+	if failed > 0 {
+		return &ExitError{Code: ExitConfigInvalid, Err: fmt.Errorf("ошибки в конфигах: %d из %d", failed, len(teams))}
+	}
+	return nil
+}
+
+// validateTeam возвращает предупреждения о конфигах команды и ошибку, если конфиги негодны.
+func validateTeam(paths ConfigPaths, teamName string) ([]config.Issue, error) {
+	cfg, err := config.Load(paths.GlobalPath, paths.TeamsDir, teamName)
+	if err != nil {
+		return nil, err
+	}
+	_, err = collector.NewConfig(cfg.Team)
+	return cfg.Warnings, err
+}
+
+func printValidateWarnings(warnings []config.Issue) {
+	for _, w := range warnings {
+		fmt.Printf("   ⚠ %s\n", w)
+	}
+}
+
+func runTeamsList(common commonOptions) error {
+	paths, err := resolveConfigPaths("", common.customPath)
+	if err != nil {
+		return err
+	}
+
+	teams, err := listTeams(paths.TeamsDir)
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		fmt.Println(team)
+	}
+	return nil
+}
+
+// listTeams возвращает имена команд по файлам teams/*.yaml.
+func listTeams(teamsDir string) ([]string, error) {
+	entries, err := os.ReadDir(teamsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		teams = append(teams, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	return teams, nil
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func completionCommand() command {
+	cmd := command{
+		name: "completion",
+		args: "bash|zsh|fish",
+		summary: "Печатает скрипт автодополнения для оболочки. Например:\n" +
+			"  bash: source <(scrum-eye completion bash)\n" +
+			"  zsh:  source <(scrum-eye completion zsh)\n" +
+			"  fish: scrum-eye completion fish | source",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		return func(positional []string) error {
+			if err := expectArgs(cmd, positional, 1, 1); err != nil {
+				return err
+			}
+			return writeCompletion(os.Stdout, positional[0])
+		}
+	}
+	return cmd
+}
+
+// commandFlags возвращает флаги команды в виде --name (однобуквенные пропускаются).
+func commandFlags(cmd command) []string {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	cmd.setup(fs)
+
+	flags := make([]string, 0)
+	fs.VisitAll(func(f *flag.Flag) {
+		if len(f.Name) > 1 {
+			flags = append(flags, "--"+f.Name)
+		}
+	})
+	return append(flags, "--help")
+}
+
+// teamArgCommands — команды, первым аргументом которых идёт имя команды из teams list.
+var teamArgCommands = []string{"report", "init", "validate", "history", "diff"}
+
+func writeCompletion(w io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		_, err := io.WriteString(w, bashCompletion())
+		return err
+	case "zsh":
+		// zsh умеет исполнять bash-дополнения через bashcompinit
+		_, err := io.WriteString(w, "autoload -U +X bashcompinit && bashcompinit\n"+bashCompletion())
+		return err
+	case "fish":
+		_, err := io.WriteString(w, fishCompletion())
+		return err
+	default:
+		return usageError(fmt.Errorf("completion: неизвестная оболочка %q (bash, zsh, fish)", shell))
+	}
+}
+
+func bashCompletion() string {
+	var sb strings.Builder
+	names := make([]string, 0)
+	for _, cmd := range commands() {
+		names = append(names, cmd.name)
+	}
+
+	sb.WriteString("# автодополнение scrum-eye для bash\n")
+	sb.WriteString("_scrum_eye() {\n")
+	sb.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" cmd=\"${COMP_WORDS[1]}\" flags=\"\"\n")
+	sb.WriteString("    if [[ $COMP_CWORD -eq 1 ]]; then\n")
+	fmt.Fprintf(&sb, "        COMPREPLY=($(compgen -W \"%s $(scrum-eye teams list 2>/dev/null)\" -- \"$cur\"))\n", strings.Join(names, " "))
+	sb.WriteString("        return\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("    case \"$cmd\" in\n")
+	for _, cmd := range commands() {
+		fmt.Fprintf(&sb, "        %s) flags=\"%s\" ;;\n", cmd.name, strings.Join(commandFlags(cmd), " "))
+	}
+	fmt.Fprintf(&sb, "        *) flags=\"%s\" ;;\n", strings.Join(commandFlags(reportCommand()), " "))
+	sb.WriteString("    esac\n")
+	sb.WriteString("    if [[ \"$cur\" == -* ]]; then\n")
+	sb.WriteString("        COMPREPLY=($(compgen -W \"$flags\" -- \"$cur\"))\n")
+	sb.WriteString("        return\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("    case \"$cmd\" in\n")
+	fmt.Fprintf(&sb, "        %s) [[ $COMP_CWORD -eq 2 ]] && COMPREPLY=($(compgen -W \"$(scrum-eye teams list 2>/dev/null)\" -- \"$cur\")) ;;\n",
+		strings.Join(teamArgCommands, "|"))
+	sb.WriteString("        teams) [[ $COMP_CWORD -eq 2 ]] && COMPREPLY=($(compgen -W \"list\" -- \"$cur\")) ;;\n")
+	sb.WriteString("        completion) [[ $COMP_CWORD -eq 2 ]] && COMPREPLY=($(compgen -W \"bash zsh fish\" -- \"$cur\")) ;;\n")
+	sb.WriteString("    esac\n")
+	sb.WriteString("}\n")
+	sb.WriteString("complete -F _scrum_eye scrum-eye\n")
+
+	return sb.String()
+}
+
+func fishCompletion() string {
+	var sb strings.Builder
+
+	sb.WriteString("# автодополнение scrum-eye для fish\n")
+	sb.WriteString("complete -c scrum-eye -f\n")
+	for _, cmd := range commands() {
+		fmt.Fprintf(&sb, "complete -c scrum-eye -n __fish_use_subcommand -a %s -d %s\n",
+			cmd.name, fishQuote(strings.SplitN(cmd.summary, "\n", 2)[0]))
+	}
+	sb.WriteString("complete -c scrum-eye -n __fish_use_subcommand -a '(scrum-eye teams list 2>/dev/null)'\n")
+
+	for _, cmd := range commands() {
+		for _, f := range commandFlags(cmd) {
+			fmt.Fprintf(&sb, "complete -c scrum-eye -n '__fish_seen_subcommand_from %s' -l %s\n",
+				cmd.name, strings.TrimPrefix(f, "--"))
+		}
+	}
+
+	fmt.Fprintf(&sb, "complete -c scrum-eye -n '__fish_seen_subcommand_from %s' -a '(scrum-eye teams list 2>/dev/null)'\n",
+		strings.Join(teamArgCommands, " "))
+	sb.WriteString("complete -c scrum-eye -n '__fish_seen_subcommand_from teams' -a list\n")
+	sb.WriteString("complete -c scrum-eye -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'\n")
+
+	return sb.String()
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`) + "'"
+}
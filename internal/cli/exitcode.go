@@ -0,0 +1,45 @@
+package cli
+
+import "errors"
+
+// Коды выхода. Отличаются, чтобы cron и CI могли понять причину сбоя без разбора текста.
+const (
+	ExitOK = 0
+	// ExitFailure — любая ошибка без отдельного кода (сеть, API, запись файлов).
+	ExitFailure = 1
+	// ExitUsage — неверные аргументы командной строки.
+	ExitUsage = 2
+	// ExitConfigMissing — нет конфига, а спросить пользователя нельзя (нет терминала или --no-input).
+	ExitConfigMissing = 3
+	// ExitConfigInvalid — конфиг есть, но в нём ошибки.
+	ExitConfigInvalid = 4
+	// ExitDeclined — пользователь ответил «нет» на предложение создать конфиг.
+	ExitDeclined = 5
+)
+
+// ExitError — ошибка с собственным кодом выхода.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode возвращает код выхода для ошибки, которую вернул Run.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
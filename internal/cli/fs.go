@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/mattn/go-isatty"
 )
 
 // ensureDirExists проверяет, что директория существует,
 // и при необходимости предлагает её создать.
-func ensureDirExists(dirPath string, prompt string) (created bool, err error) {
+func ensureDirExists(p prompter, dirPath string, prompt string) (created bool, err error) {
 	info, err := os.Stat(dirPath)
 	if err == nil {
 		if info.IsDir() {
@@ -23,21 +25,25 @@ func ensureDirExists(dirPath string, prompt string) (created bool, err error) {
 	}
 
 	// директории нет
-	if !askYesNo(prompt, false) {
-		return false, fmt.Errorf("директория %s не существует и не была создана", dirPath)
+	ok, err := p.confirm(prompt)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, declined(fmt.Errorf("директория %s не существует и не была создана", dirPath))
 	}
 
 	if err := os.MkdirAll(dirPath, 0o755); err != nil {
 		return false, fmt.Errorf("не удалось создать директорию %s: %w", dirPath, err)
 	}
 
-	fmt.Println("Создана директория:", dirPath)
+	fmt.Fprintln(os.Stderr, "Создана директория:", dirPath)
 	return true, nil
 }
 
 // ensureGlobalConfig проверяет наличие global.yaml,
 // и при отсутствии предлагает создать шаблон.
-func ensureGlobalConfig(globalPath string) error {
+func ensureGlobalConfig(p prompter, globalPath string) error {
 	_, err := os.Stat(globalPath)
 	if err == nil {
 		return nil
@@ -47,22 +53,26 @@ func ensureGlobalConfig(globalPath string) error {
 	}
 
 	msg := fmt.Sprintf("Файл global.yaml (%s) не найден. Создать шаблонный файл?", globalPath)
-	if !askYesNo(msg, false) {
-		return fmt.Errorf("global.yaml не существует и не был создан")
+	ok, err := p.confirm(msg)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return declined(fmt.Errorf("global.yaml не существует и не был создан"))
 	}
 
 	if err := writeGlobalTemplate(globalPath); err != nil {
 		return err
 	}
 
-	fmt.Println("Создан шаблон global.yaml:", globalPath)
+	fmt.Fprintln(os.Stderr, "Создан шаблон global.yaml:", globalPath)
 	return nil
 }
 
 // ensureTeamConfig проверяет наличие config-файла команды,
 // и при отсутствии предлагает создать шаблон.
-func ensureTeamConfig(teamsDir, teamFile, teamName string) (created bool, err error) {
-	dirCreated, err := ensureDirExists(
+func ensureTeamConfig(p prompter, teamsDir, teamFile, teamName string) (created bool, err error) {
+	dirCreated, err := ensureDirExists(p,
 		teamsDir,
 		fmt.Sprintf("Папка с командами (%s) не найдена. Создать её?", teamsDir))
 
@@ -80,21 +90,73 @@ func ensureTeamConfig(teamsDir, teamFile, teamName string) (created bool, err er
 
 	msg := fmt.Sprintf("Файл конфигурации команды (%s) не найден. Создать шаблон для команды '%s'?",
 		teamFile, teamName)
-	if !askYesNo(msg, false) {
-		return false, fmt.Errorf("конфиг команды %s не существует и не был создан", teamName)
+	ok, err := p.confirm(msg)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, declined(fmt.Errorf("конфиг команды %s не существует и не был создан", teamName))
 	}
 
 	if err := writeTeamTemplate(teamFile, teamName); err != nil {
 		return false, err
 	}
 
-	fmt.Println("Создан шаблон конфигурации команды:", teamFile)
+	fmt.Fprintln(os.Stderr, "Создан шаблон конфигурации команды:", teamFile)
 	return true, nil
 }
 
+// prompter решает, как отвечать на вопросы о создании конфигов:
+// спрашивать пользователя, соглашаться автоматически (--yes) или сразу падать.
+type prompter struct {
+	assumeYes   bool
+	interactive bool
+}
+
+// newPrompter включает вопросы, только если stdin — терминал и не передан --no-input.
+func newPrompter(opts commonOptions) prompter {
+	return prompter{
+		assumeYes:   opts.yes,
+		interactive: !opts.noInput && stdinIsTerminal(),
+	}
+}
+
+// confirm возвращает ответ на вопрос. Без терминала и без --yes возвращает ошибку
+// с кодом ExitConfigMissing вместо того, чтобы навсегда повиснуть на чтении stdin.
+// Вопросы пишутся в stderr: stdout остаётся за отчётом.
+func (p prompter) confirm(question string) (bool, error) {
+	if p.assumeYes {
+		fmt.Fprintf(os.Stderr, "%s [y/N]: y (--yes)\n", question)
+		return true, nil
+	}
+	if p.interactive {
+		if answer, ok := askYesNo(question, false); ok {
+			return answer, nil
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+	return false, &ExitError{
+		Code: ExitConfigMissing,
+		Err:  fmt.Errorf("не удалось спросить «%s»: нет терминала или указан --no-input; запусти с --yes, чтобы создать шаблоны", question),
+	}
+}
+
+// declined помечает отказ пользователя создать конфиг кодом ExitDeclined.
+func declined(err error) error {
+	return &ExitError{Code: ExitDeclined, Err: err}
+}
+
+// stdinIsTerminal сообщает, что stdin подключён к терминалу, а не к файлу или пайпу.
+// Проверка режима ModeCharDevice не годится: символьное устройство и /dev/null у cron.
+func stdinIsTerminal() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
 // askYesNo задаёт вопрос пользователю и возвращает true/false.
 // defaultYes=false значит, что Enter без ввода = "нет".
-func askYesNo(question string, defaultYes bool) bool {
+// ok=false, если stdin закрылся раньше, чем пришёл ответ (например, /dev/null у cron).
+func askYesNo(question string, defaultYes bool) (answer bool, ok bool) {
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
@@ -103,20 +165,26 @@ func askYesNo(question string, defaultYes bool) bool {
 			defStr = "Y/n"
 		}
 
-		fmt.Printf("%s [%s]: ", question, defStr)
-		text, _ := reader.ReadString('\n')
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", question, defStr)
+		text, err := reader.ReadString('\n')
+		if err != nil && text == "" {
+			return false, false
+		}
 		text = strings.TrimSpace(strings.ToLower(text))
 
 		if text == "" {
-			return defaultYes
+			return defaultYes, true
 		}
 		if text == "y" || text == "yes" || text == "д" || text == "да" {
-			return true
+			return true, true
 		}
 		if text == "n" || text == "no" || text == "н" || text == "нет" {
-			return false
+			return false, true
+		}
+		if err != nil {
+			return false, false
 		}
 
-		fmt.Println("Пожалуйста, ответь 'y' или 'n'.")
+		fmt.Fprintln(os.Stderr, "Пожалуйста, ответь 'y' или 'n'.")
 	}
 }
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"scrum-eye/internal/config"
+	"scrum-eye/internal/diff"
+	"scrum-eye/internal/report"
+	"scrum-eye/internal/storage"
+)
+
+const dateLayout = "2006-01-02"
+
+func historyCommand() command {
+	cmd := command{
+		name:    "history",
+		args:    "<team>",
+		summary: "Показывает сохранённые снимки команды, от новых к старым.",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		var common commonOptions
+		addPathFlag(fs, &common)
+		limit := fs.Int("limit", 20, "сколько снимков показать (0 — все)")
+
+		return func(positional []string) error {
+			if err := expectArgs(cmd, positional, 1, 1); err != nil {
+				return err
+			}
+			return runHistory(common, positional[0], *limit)
+		}
+	}
+	return cmd
+}
+
+func diffCommand() command {
+	cmd := command{
+		name: "diff",
+		args: "<team>",
+		summary: "Сравнивает два сохранённых снимка без запросов к API.\n" +
+			"По умолчанию — последний снимок с последним снимком предыдущего дня.",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		var common commonOptions
+		addPathFlag(fs, &common)
+		from := fs.String("from", "", "дата снимка-базы, YYYY-MM-DD (берётся последний снимок дня)")
+		to := fs.String("to", "", "дата сравниваемого снимка, YYYY-MM-DD (по умолчанию последний снимок)")
+		format := fs.String("format", "console", "формат: console или json")
+
+		return func(positional []string) error {
+			if err := expectArgs(cmd, positional, 1, 1); err != nil {
+				return err
+			}
+			if *format != string(report.FormatConsole) && *format != string(report.FormatJSON) {
+				return usageError(fmt.Errorf("diff: неизвестный формат %q (console, json)", *format))
+			}
+			return runDiff(common, positional[0], *from, *to, report.Format(*format))
+		}
+	}
+	return cmd
+}
+
+// openStore открывает хранилище снимков по storage.path из global.yaml.
+func openStore(common commonOptions, teamName string) (*storage.FileSystem, ConfigPaths, error) {
+	paths, err := resolveConfigPaths(teamName, common.customPath)
+	if err != nil {
+		return nil, ConfigPaths{}, err
+	}
+
+	global, err := config.LoadGlobal(paths.GlobalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, paths, &ExitError{Code: ExitConfigMissing, Err: fmt.Errorf("global.yaml не найден (%s): создай его через scrum-eye init", paths.GlobalPath)}
+	}
+	if err != nil {
+		return nil, paths, err
+	}
+
+	return storage.NewFileSystem(resolveStoragePath(paths, global.Storage.Path)), paths, nil
+}
+
+func runHistory(common commonOptions, teamName string, limit int) error {
+	store, _, err := openStore(common, teamName)
+	if err != nil {
+		return err
+	}
+
+	infos, err := store.List(teamName)
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		fmt.Println("Снимков пока нет: они сохраняются при каждом запуске scrum-eye report", teamName)
+		return nil
+	}
+
+	shown := 0
+	for i := len(infos) - 1; i >= 0 && (limit <= 0 || shown < limit); i-- {
+		snapshot, err := store.Load(infos[i])
+		if err != nil {
+			return err
+		}
+
+		sprint, items := "—", 0
+		if snapshot.Project != nil && snapshot.Project.CurrentSprint != nil {
+			sprint = snapshot.Project.CurrentSprint.Name
+			items = len(snapshot.Project.CurrentSprint.WorkItems)
+		}
+		fmt.Printf("%s  %-24s %4d items\n", infos[i].TakenAt.Local().Format("2006-01-02 15:04"), sprint, items)
+		shown++
+	}
+
+	if shown < len(infos) {
+		fmt.Printf("… и ещё %d (--limit=0, чтобы показать все)\n", len(infos)-shown)
+	}
+	return nil
+}
+
+func runDiff(common commonOptions, teamName, from, to string, format report.Format) error {
+	store, _, err := openStore(common, teamName)
+	if err != nil {
+		return err
+	}
+
+	current, err := loadSnapshotByDate(store, teamName, to)
+	if err != nil {
+		return err
+	}
+
+	var baseline *storage.Snapshot
+	if from == "" {
+		// последний снимок до начала дня сравниваемого снимка
+		y, m, d := current.TakenAt.Local().Date()
+		dayStart := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+		baseline, err = store.LatestBefore(teamName, dayStart.Add(-time.Nanosecond))
+		if errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("нет снимков раньше %s", dayStart.Format(dateLayout))
+		}
+	} else {
+		baseline, err = loadSnapshotByDate(store, teamName, from)
+	}
+	if err != nil {
+		return err
+	}
+
+	res := diff.Compare(baseline.Project, current.Project, baseline.TakenAt)
+
+	if format == report.FormatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+
+	fmt.Printf("Снимок %s против %s\n", current.TakenAt.Local().Format("2006-01-02 15:04"),
+		baseline.TakenAt.Local().Format("2006-01-02 15:04"))
+	report.PrintDiff(res)
+	return nil
+}
+
+// loadSnapshotByDate загружает последний снимок дня date (YYYY-MM-DD) или самый свежий, если date пустая.
+func loadSnapshotByDate(store *storage.FileSystem, teamName, date string) (*storage.Snapshot, error) {
+	if date == "" {
+		snapshot, err := store.LatestBefore(teamName, time.Now())
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("у команды %s ещё нет снимков", teamName)
+		}
+		return snapshot, err
+	}
+
+	day, err := time.ParseInLocation(dateLayout, date, time.Local)
+	if err != nil {
+		return nil, usageError(fmt.Errorf("дата должна быть в формате YYYY-MM-DD: %s", date))
+	}
+
+	snapshot, err := store.LoadByDate(teamName, day)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("нет снимка за %s", date)
+	}
+	return snapshot, err
+}
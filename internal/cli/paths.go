@@ -43,3 +43,15 @@ func resolveConfigPaths(teamName, customPath string) (ConfigPaths, error) {
 		TeamName:   teamName,
 	}, nil
 }
+
+// resolveStoragePath возвращает абсолютный путь к хранилищу снимков.
+// Относительный storage.path считается от папки с конфигами.
+func resolveStoragePath(paths ConfigPaths, storagePath string) string {
+	if storagePath == "" {
+		storagePath = "data"
+	}
+	if filepath.IsAbs(storagePath) {
+		return storagePath
+	}
+	return filepath.Join(paths.RootDir, storagePath)
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"scrum-eye/internal/analysis"
+	"scrum-eye/internal/config"
+	"scrum-eye/internal/report"
+)
+
+// DefaultConcurrency — сколько команд собирается одновременно, если не задано
+// ни --concurrency, ни portfolio.concurrency в global.yaml.
+const DefaultConcurrency = 4
+
+// runPortfolio собирает все команды (--all) или команды группы (--group) параллельно
+// и выводит портфельный обзор: по строке на команду. Снимки команд сохраняются так же,
+// как при обычном report. Ошибка одной команды не останавливает остальные: команда
+// попадает в обзор с текстом ошибки, а код выхода становится ненулевым.
+func runPortfolio(opts options) error {
+	ctx := context.Background()
+
+	format, err := report.ParseFormat(opts.format)
+	if err != nil {
+		return usageError(err)
+	}
+
+	paths, err := resolveConfigPaths("", opts.customPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(paths.GlobalPath); err != nil {
+		return &ExitError{Code: ExitConfigMissing, Err: fmt.Errorf("global.yaml не найден (%s): создай его через scrum-eye init", paths.GlobalPath)}
+	}
+
+	global, err := config.LoadGlobal(paths.GlobalPath)
+	var invalid *config.ValidationError
+	if errors.As(err, &invalid) {
+		return &ExitError{Code: ExitConfigInvalid, Err: err}
+	}
+	if err != nil {
+		return err
+	}
+
+	teams, err := portfolioTeams(opts, paths, global.Portfolio)
+	if err != nil {
+		return err
+	}
+
+	concurrency := opts.concurrency
+	if concurrency <= 0 {
+		concurrency = global.Portfolio.Concurrency
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	now := time.Now()
+	rows := collectPortfolio(ctx, opts, teams, concurrency, now)
+
+	failed := 0
+	for _, row := range rows {
+		if row.Error != "" {
+			failed++
+		}
+	}
+
+	portfolio := report.NewPortfolio(opts.group, rows, now)
+	if err := renderPortfolio(portfolio, format, opts.output); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return &ExitError{Code: ExitFailure, Err: fmt.Errorf("не удалось собрать данные %d из %d команд", failed, len(rows))}
+	}
+	return nil
+}
+
+// portfolioTeams возвращает команды для обзора: все из папки teams или участников группы.
+func portfolioTeams(opts options, paths ConfigPaths, cfg config.PortfolioConfig) ([]string, error) {
+	if opts.all {
+		teams, err := listTeams(paths.TeamsDir)
+		if err != nil {
+			return nil, err
+		}
+		if len(teams) == 0 {
+			return nil, &ExitError{Code: ExitConfigMissing, Err: fmt.Errorf("в %s нет ни одного конфига команды", paths.TeamsDir)}
+		}
+		return teams, nil
+	}
+
+	teams, ok := cfg.Groups[opts.group]
+	if !ok {
+		groups := make([]string, 0, len(cfg.Groups))
+		for name := range cfg.Groups {
+			groups = append(groups, name)
+		}
+		sort.Strings(groups)
+
+		msg := fmt.Sprintf("группа %q не найдена в portfolio.groups", opts.group)
+		if len(groups) > 0 {
+			msg += ", есть: " + strings.Join(groups, ", ")
+		}
+		return nil, usageError(errors.New(msg))
+	}
+	return teams, nil
+}
+
+// collectPortfolio собирает команды параллельно, не больше concurrency одновременно.
+// Строки возвращаются в порядке teams.
+func collectPortfolio(ctx context.Context, opts options, teams []string, concurrency int,
+	now time.Time) []analysis.PortfolioTeam {
+	rows := make([]analysis.PortfolioTeam, len(teams))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, team := range teams {
+		wg.Add(1)
+		go func(i int, team string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			row, err := collectPortfolioTeam(ctx, opts, team, now)
+			if err != nil {
+				row = analysis.PortfolioError(team, err)
+			}
+			rows[i] = row
+		}(i, team)
+	}
+
+	wg.Wait()
+	return rows
+}
+
+func collectPortfolioTeam(ctx context.Context, opts options, team string,
+	now time.Time) (analysis.PortfolioTeam, error) {
+	paths, err := resolveConfigPaths(team, opts.customPath)
+	if err != nil {
+		return analysis.PortfolioTeam{}, err
+	}
+	if _, err := os.Stat(paths.TeamFile); err != nil {
+		return analysis.PortfolioTeam{}, fmt.Errorf("конфиг команды не найден: %s", paths.TeamFile)
+	}
+
+	cfg, err := loadTeamConfig(paths)
+	if err != nil {
+		return analysis.PortfolioTeam{}, err
+	}
+
+	doc, _, err := buildTeamReport(ctx, opts, cfg, paths, now)
+	if err != nil {
+		return analysis.PortfolioTeam{}, err
+	}
+
+	return analysis.AnalyzePortfolioTeam(analysis.PortfolioInput{
+		Team:     team,
+		Sprint:   doc.Project.CurrentSprint,
+		WIP:      doc.WIP,
+		Aging:    doc.Aging,
+		Builds:   doc.Builds,
+		Forecast: doc.Forecast,
+	}, now), nil
+}
+
+// renderPortfolio выводит обзор в нужном формате в stdout или в файл output.
+func renderPortfolio(p *report.Portfolio, format report.Format, output string) error {
+	if format == report.FormatConsole {
+		report.PrintPortfolio(p)
+		return nil
+	}
+
+	return writeOutput(output, func(w io.Writer) error {
+		switch format {
+		case report.FormatJSON:
+			return report.WritePortfolioJSON(w, p)
+		case report.FormatNDJSON:
+			return report.WritePortfolioNDJSON(w, p)
+		case report.FormatMarkdown:
+			return report.WritePortfolioMarkdown(w, p)
+		case report.FormatHTML:
+			return report.WritePortfolioHTML(w, p)
+		}
+		return nil
+	})
+}
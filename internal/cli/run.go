@@ -2,75 +2,430 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"scrum-eye/internal/analysis"
 	"scrum-eye/internal/collector"
 	"scrum-eye/internal/config"
+	"scrum-eye/internal/diff"
+	"scrum-eye/internal/domain"
 	"scrum-eye/internal/report"
 	"scrum-eye/internal/sources/azureboards"
+	"scrum-eye/internal/sources/teamcity"
+	"scrum-eye/internal/storage"
+	"strings"
+	"time"
 )
 
-func Run(args []string) error {
+// runReport собирает данные команды, сохраняет снимок и выводит отчёт.
+func runReport(opts options) error {
 	ctx := context.Background()
 
-	teamName, customPath, err := parseArgs(args)
+	format, err := report.ParseFormat(opts.format)
+	if err != nil {
+		return usageError(err)
+	}
+
+	paths, err := resolveConfigPaths(opts.teamName, opts.customPath)
 	if err != nil {
-		printUsage()
 		return err
 	}
-	if teamName == "" {
-		printUsage()
-		return fmt.Errorf("team name is required")
+
+	err = ensureConfigurationExists(newPrompter(opts.commonOptions), paths)
+	if err != nil {
+		return err
 	}
 
-	paths, err := resolveConfigPaths(teamName, customPath)
+	cfg, err := loadTeamConfig(paths)
 	if err != nil {
 		return err
 	}
 
-	err = ensureConfigurationExists(paths)
+	now := time.Now()
+	doc, store, err := buildTeamReport(ctx, opts, cfg, paths, now)
 	if err != nil {
 		return err
 	}
 
+	output := opts.output
+	if output == "" && format == report.FormatHTML {
+		output = store.ReportPath(paths.TeamName, now, "html")
+	}
+
+	if opts.cfdExport != "" {
+		if err := exportCFD(doc.CFD, opts.cfdExport); err != nil {
+			return err
+		}
+	}
+
+	defer ctx.Done()
+	return render(doc, format, output)
+}
+
+// loadTeamConfig загружает конфиги команды; ошибки проверки получают код ExitConfigInvalid.
+func loadTeamConfig(paths ConfigPaths) (*config.AppConfig, error) {
 	cfg, err := config.Load(paths.GlobalPath, paths.TeamsDir, paths.TeamName)
+	var invalid *config.ValidationError
+	if errors.As(err, &invalid) {
+		printConfigWarnings(invalid.Warnings)
+		return nil, &ExitError{Code: ExitConfigInvalid, Err: err}
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
+	printConfigWarnings(cfg.Warnings)
+	return cfg, nil
+}
+
+// printConfigWarnings выводит замечания к конфигам в stderr, чтобы не портить машинный вывод.
+func printConfigWarnings(warnings []config.Issue) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "⚠ %s\n", w)
+	}
+}
 
+// buildTeamReport собирает данные команды, сохраняет снимок и считает аналитику для отчёта.
+func buildTeamReport(ctx context.Context, opts options, cfg *config.AppConfig, paths ConfigPaths,
+	now time.Time) (*report.Document, *storage.FileSystem, error) {
 	boardsClient := azureboards.NewClient(cfg.Team.AzureDevOps)
 
-	dataCollector := collector.NewCollector(boardsClient)
+	collectorCfg, err := collector.NewConfig(cfg.Team)
+	if err != nil {
+		return nil, nil, &ExitError{Code: ExitConfigInvalid, Err: err}
+	}
+	collectorCfg.Sprint = collector.SprintSelector{Name: opts.sprint, Offset: opts.sprintOffset}
+	if opts.sprint == "" {
+		collectorCfg.Sprint.Name = cfg.Global.Defaults.SprintMode
+	}
+
+	dataCollector := collector.NewCollector(boardsClient, collectorCfg)
+	if cfg.Team.TeamCity.BaseURL != "" && len(cfg.Team.TeamCity.BuildConfigs) > 0 {
+		dataCollector.WithCI(teamcity.NewClient(cfg.Team.TeamCity))
+	}
+	if len(collectorCfg.Repos) > 0 {
+		dataCollector.WithRepos(boardsClient)
+	}
 
 	project, err := dataCollector.Collect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	report.PrintCurrentSprint(project)
+	store := storage.NewFileSystem(resolveStoragePath(paths, cfg.Global.Storage.Path))
 
-	defer ctx.Done()
+	// снимки и сравнение с базой имеют смысл только для идущего спринта:
+	// прошлый спринт смотрим как есть, не портя историю
+	var changes *diff.Result
+	var scope *analysis.ScopeChange
+	if project.CurrentSprint.IsCurrent() {
+		changes, err = diffWithBaseline(store, cfg.Team.Diff, paths.TeamName, project, now)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := saveSnapshot(store, cfg.Global.Storage, paths.TeamName, project, now); err != nil {
+			return nil, nil, err
+		}
+
+		scope, err = scopeSinceCommitment(store, paths.TeamName, project, now)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	doc := report.NewDocument(paths.TeamName, project, now)
+	doc.Diff = changes
+	doc.Scope = scope
+	doc.WIP = analysis.AnalyzeWIP(project.CurrentSprint, cfg.Team.Metrics)
+	doc.Builds = analysis.AnalyzeBuilds(project.BuildConfigs, cfg.Team.Metrics.MaxBuilds, now)
+
+	snapshots, err := loadSprintSnapshots(store, paths.TeamName, project.CurrentSprint, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc.Burndown = analysis.AnalyzeBurndown(project.CurrentSprint, snapshots, now)
+	doc.CFD = analysis.AnalyzeCFD(project.CurrentSprint, snapshots, now)
+	commitments, err := loadCommitments(store, paths.TeamName, project.SprintHistory)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc.Velocity = analysis.AnalyzeVelocity(project.SprintHistory, project.CurrentSprint, commitments)
+	// cycle time считается по тому же окну спринтов, для которого загружена история состояний
+	flowSprints := append([]domain.Sprint{}, domain.RecentSprints(project.SprintHistory, collectorCfg.FlowSprints)...)
+	doc.Flow = analysis.AnalyzeFlow(append(flowSprints, *project.CurrentSprint))
+	if project.CurrentSprint.IsCurrent() {
+		doc.Aging = analysis.AnalyzeAging(project.CurrentSprint, doc.Flow, now)
+		doc.Forecast = analysis.ForecastCompletion(project.CurrentSprint, project.SprintHistory, now,
+			analysis.ForecastOptions{Trials: cfg.Team.Metrics.ForecastTrials})
+		if doc.Forecast == nil {
+			doc.Warnings = append(doc.Warnings,
+				"completion forecast skipped: not enough throughput history, no backlog items were closed "+
+					"in past sprints or so far in this one (past sprints are loaded with metrics.velocitySprints)")
+		}
+	}
+	doc.Warnings = append(doc.Warnings, doc.WIP.Warnings...)
+
+	return doc, store, nil
+}
+
+// exportCFD сохраняет данные накопительной диаграммы потока в CSV или JSON (по расширению файла).
+func exportCFD(cfd *analysis.CFD, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("не удалось создать директорию для %s: %w", path, err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("не удалось создать файл %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = report.WriteCFDJSON(f, cfd)
+	} else {
+		err = report.WriteCFDCSV(f, cfd)
+	}
+	if err != nil {
+		return fmt.Errorf("не удалось записать CFD в %s: %w", path, err)
+	}
+
+	fmt.Fprintln(os.Stderr, "CFD сохранена:", path)
+	return nil
+}
+
+// render выводит отчёт в нужном формате в stdout или в файл output.
+// Консольный формат всегда пишется в stdout.
+func render(doc *report.Document, format report.Format, output string) error {
+	if format == report.FormatConsole {
+		report.PrintConsole(doc)
+		return nil
+	}
+
+	return writeOutput(output, func(w io.Writer) error {
+		switch format {
+		case report.FormatJSON:
+			return report.WriteJSON(w, doc)
+		case report.FormatNDJSON:
+			return report.WriteNDJSON(w, doc)
+		case report.FormatMarkdown:
+			return report.WriteMarkdown(w, doc)
+		case report.FormatHTML:
+			return report.WriteHTML(w, doc)
+		}
+		return nil
+	})
+}
+
+// writeOutput вызывает write для stdout или, если output задан, для файла output.
+func writeOutput(output string, write func(w io.Writer) error) error {
+	w := io.Writer(os.Stdout)
+	if output != "" {
+		if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+			return fmt.Errorf("не удалось создать директорию для %s: %w", output, err)
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("не удалось создать файл отчёта %s: %w", output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := write(w); err != nil {
+		return err
+	}
+
+	if output != "" {
+		fmt.Fprintln(os.Stderr, "Отчёт сохранён:", output)
+	}
 	return nil
 }
 
-func ensureConfigurationExists(paths ConfigPaths) error {
-	created, err := ensureDirExists(paths.RootDir,
+func ensureConfigurationExists(p prompter, paths ConfigPaths) error {
+	created, err := ensureDirExists(p, paths.RootDir,
 		fmt.Sprintf("Папка с конфигами (%s) не найдена. Создать её?", paths.RootDir))
 	if err != nil {
 		return err
 	}
 
-	if err := ensureGlobalConfig(paths.GlobalPath); err != nil {
+	if err := ensureGlobalConfig(p, paths.GlobalPath); err != nil {
 		return err
 	}
 
-	created, err = ensureTeamConfig(paths.TeamsDir, paths.TeamFile, paths.TeamName)
+	created, err = ensureTeamConfig(p, paths.TeamsDir, paths.TeamFile, paths.TeamName)
 	if err != nil {
 		return err
 	}
 
 	if created {
-		fmt.Println()
-		fmt.Println("✅ Конфигурация готова к использованию:")
-		fmt.Println("  Root:   ", paths.RootDir)
-		fmt.Println("  Global: ", paths.GlobalPath)
-		fmt.Println("  Team:   ", paths.TeamFile)
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, "✅ Конфигурация готова к использованию:")
+		fmt.Fprintln(os.Stderr, "  Root:   ", paths.RootDir)
+		fmt.Fprintln(os.Stderr, "  Global: ", paths.GlobalPath)
+		fmt.Fprintln(os.Stderr, "  Team:   ", paths.TeamFile)
+	}
+
+	return nil
+}
+
+// diffWithBaseline сравнивает проект с последним снимком, сделанным diff.baselineDays
+// календарных дней назад или раньше (при baselineDays=1 — последний вчерашний запуск).
+// Если такого снимка ещё нет, возвращает nil без ошибки.
+func diffWithBaseline(store *storage.FileSystem, cfg config.DiffConfig, teamName string,
+	project *domain.Project, now time.Time) (*diff.Result, error) {
+	days := cfg.BaselineDays
+	if days <= 0 {
+		days = 1
+	}
+
+	y, m, d := now.Date()
+	cutoff := time.Date(y, m, d, 0, 0, 0, 0, now.Location()).AddDate(0, 0, -(days - 1))
+
+	baseline, err := store.LatestBefore(teamName, cutoff.Add(-time.Nanosecond))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(baseline.Project, project, baseline.TakenAt), nil
+}
+
+// scopeSinceCommitment сравнивает спринт со снимком обязательств — составом спринта
+// к концу первого дня, когда обычно идёт планирование. До начала спринта возвращается nil.
+// Пока первый день не закончился, обязательства считаются на лету и не сохраняются;
+// после — фиксируются один раз из commitmentBaseline и дальше читаются из хранилища.
+func scopeSinceCommitment(store *storage.FileSystem, teamName string, project *domain.Project,
+	now time.Time) (*analysis.ScopeChange, error) {
+	sprint := project.CurrentSprint
+	if sprint == nil || sprint.StartDate == nil || now.Before(*sprint.StartDate) {
+		return nil, nil
+	}
+
+	commitment, err := store.LoadCommitment(teamName, sprint.ID)
+	if err == nil {
+		if commitment.Project == nil {
+			return nil, nil
+		}
+		return analysis.AnalyzeScope(commitment.Project.CurrentSprint, commitment.TakenAt, sprint), nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	planningEnd := sprint.StartDate.AddDate(0, 0, 1)
+	baseline, takenAt, err := commitmentBaseline(store, teamName, project, planningEnd, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !now.Before(planningEnd) {
+		if err := store.SaveCommitment(teamName, sprint.ID, baseline, takenAt); err != nil {
+			return nil, err
+		}
+	}
+	return analysis.AnalyzeScope(baseline.CurrentSprint, takenAt.Truncate(time.Second), sprint), nil
+}
+
+// commitmentBaseline выбирает, с чем сравнивать спринт: последний снимок этого спринта,
+// сделанный не позже planningEnd; без такого снимка — элементы, которые по истории ревизий
+// были в спринте к planningEnd (Sprint.CommittedIDs); если истории нет — текущий состав.
+func commitmentBaseline(store *storage.FileSystem, teamName string, project *domain.Project,
+	planningEnd, now time.Time) (*domain.Project, time.Time, error) {
+	sprint := project.CurrentSprint
+
+	snapshots, err := store.LoadRange(teamName, sprint.StartDate.AddDate(0, 0, -1), planningEnd)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		s := snapshots[i]
+		if s.Project != nil && s.Project.CurrentSprint != nil && s.Project.CurrentSprint.ID == sprint.ID {
+			return s.Project, s.TakenAt, nil
+		}
+	}
+
+	if sprint.CommittedIDs == nil {
+		return project, now, nil
+	}
+
+	committed := map[int]bool{}
+	for _, id := range sprint.CommittedIDs {
+		committed[id] = true
+	}
+	planned := *sprint
+	planned.WorkItems = make([]domain.WorkItem, 0, len(sprint.CommittedIDs))
+	for _, wi := range sprint.WorkItems {
+		if committed[wi.ID] {
+			planned.WorkItems = append(planned.WorkItems, wi)
+		}
+	}
+
+	takenAt := planningEnd
+	if now.Before(takenAt) {
+		takenAt = now
+	}
+	return &domain.Project{CurrentSprint: &planned}, takenAt, nil
+}
+
+// loadCommitments загружает сохранённые снимки обязательств прошлых спринтов по ID спринта.
+// Спринты без снимка в результат не попадают.
+func loadCommitments(store *storage.FileSystem, teamName string,
+	history []domain.Sprint) (map[string]*domain.Sprint, error) {
+	result := map[string]*domain.Sprint{}
+	for _, sprint := range history {
+		commitment, err := store.LoadCommitment(teamName, sprint.ID)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if commitment.Project != nil && commitment.Project.CurrentSprint != nil {
+			result[sprint.ID] = commitment.Project.CurrentSprint
+		}
+	}
+	return result, nil
+}
+
+// loadSprintSnapshots загружает сохранённые снимки, сделанные с начала спринта.
+func loadSprintSnapshots(store *storage.FileSystem, teamName string, sprint *domain.Sprint,
+	now time.Time) ([]analysis.SprintSnapshot, error) {
+	if sprint == nil || sprint.StartDate == nil {
+		return nil, nil
+	}
+
+	snapshots, err := store.LoadRange(teamName, sprint.StartDate.AddDate(0, 0, -1), now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]analysis.SprintSnapshot, 0, len(snapshots))
+	for _, s := range snapshots {
+		if s.Project == nil {
+			continue
+		}
+		result = append(result, analysis.SprintSnapshot{At: s.TakenAt, Sprint: s.Project.CurrentSprint})
+	}
+	return result, nil
+}
+
+// saveSnapshot сохраняет собранный проект и чистит старые снимки по политике хранения.
+func saveSnapshot(store *storage.FileSystem, cfg config.StorageConfig, teamName string,
+	project *domain.Project, now time.Time) error {
+	if _, err := store.Save(teamName, project, now); err != nil {
+		return err
+	}
+
+	policy := storage.RetentionPolicy{
+		MaxAge:   time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		MaxCount: cfg.MaxSnapshots,
+	}
+	if _, err := store.Prune(teamName, policy, now); err != nil {
+		return err
 	}
 
 	return nil
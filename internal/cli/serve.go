@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"scrum-eye/internal/storage"
+)
+
+func serveCommand() command {
+	cmd := command{
+		name:    "serve",
+		args:    "",
+		summary: "Раздаёт архив HTML-отчётов всех команд из <storage.path> по HTTP.",
+	}
+	cmd.setup = func(fs *flag.FlagSet) func([]string) error {
+		var common commonOptions
+		addPathFlag(fs, &common)
+		addr := fs.String("addr", "127.0.0.1:8080", "адрес, на котором слушать")
+
+		return func(positional []string) error {
+			if err := expectArgs(cmd, positional, 0, 0); err != nil {
+				return err
+			}
+			return runServe(common, *addr)
+		}
+	}
+	return cmd
+}
+
+func runServe(common commonOptions, addr string) error {
+	store, _, err := openStore(common, "")
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           &reportsHandler{store: store},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(os.Stderr, "Архив отчётов доступен на http://%s/\n", addr)
+	return server.ListenAndServe()
+}
+
+// reportsHandler отдаёт список команд с их отчётами (/) и сами отчёты (/<team>/<файл>.html).
+// /<team>/latest перенаправляет на самый свежий отчёт команды.
+type reportsHandler struct {
+	store *storage.FileSystem
+}
+
+type reportsIndexTeam struct {
+	Name    string
+	Reports []string
+}
+
+var reportsIndex = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="ru"><head><meta charset="utf-8"><title>scrum-eye</title>
+<style>body{font-family:sans-serif;margin:2em}li{margin:.2em 0}</style></head>
+<body><h1>scrum-eye</h1>
+{{range .}}<h2>{{.Name}}</h2><ul>{{$team := .Name}}{{range .Reports}}<li><a href="/{{$team}}/{{.}}">{{.}}</a></li>{{end}}</ul>
+{{else}}<p>Отчётов пока нет: HTML-отчёты сохраняются при scrum-eye report &lt;team&gt; --format=html</p>{{end}}
+</body></html>
+`))
+
+func (h *reportsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "":
+		h.serveIndex(w)
+	case len(parts) == 2 && validPathPart(parts[0]) && parts[1] == "latest":
+		h.serveLatest(w, r, parts[0])
+	case len(parts) == 2 && validPathPart(parts[0]) && validPathPart(parts[1]) && filepath.Ext(parts[1]) == ".html":
+		http.ServeFile(w, r, filepath.Join(h.store.ReportsDir(parts[0]), parts[1]))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *reportsHandler) serveIndex(w http.ResponseWriter) {
+	teams, err := h.store.Teams()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	index := make([]reportsIndexTeam, 0, len(teams))
+	for _, team := range teams {
+		reports, err := h.store.Reports(team, "html")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(reports) > 0 {
+			index = append(index, reportsIndexTeam{Name: team, Reports: reports})
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = reportsIndex.Execute(w, index)
+}
+
+func (h *reportsHandler) serveLatest(w http.ResponseWriter, r *http.Request, team string) {
+	reports, err := h.store.Reports(team, "html")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(reports) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	http.Redirect(w, r, "/"+team+"/"+reports[0], http.StatusFound)
+}
+
+// validPathPart не пускает за пределы хранилища.
+func validPathPart(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, `/\`)
+}
@@ -9,22 +9,39 @@ import (
 func writeGlobalTemplate(path string) error {
 	content := `# Глобальная конфигурация для scrum-eye
 azure:
-  organization: "https://dev.azure.com/your-org"
+  # имя организации из адреса https://dev.azure.com/<организация>
+  organization: "your-org"
 
+# Токены лучше не хранить в файле: ${NAME} подставляет переменную окружения,
+# ${NAME:-значение} — со значением по умолчанию.
 auth:
-  azurePat: "CHANGE_ME_AZURE_PAT"
-  teamcityToken: "CHANGE_ME_TEAMCITY_TOKEN"
+  azurePat: "${AZURE_DEVOPS_PAT}"
+  teamcityToken: "${TEAMCITY_TOKEN:-}"
+  # или путь к файлу с токеном (относительно папки с global.yaml):
+  # azurePatFile: "~/.secrets/azure-pat"
+  # teamcityTokenFile: "~/.secrets/teamcity-token"
+  # или команда в стиле git credential helper — её спросят о недостающих токенах:
+  # credentialHelper: "git credential-manager"
 
 teamcity:
   baseUrl: "https://teamcity.example.com"
 
 storage:
   path: "./data"
+  retentionDays: 90
+  maxSnapshots: 0
 
 defaults:
   branch: "develop"
   maxBuilds: 20
   sprintMode: "current"
+
+# Портфельный обзор: scrum-eye report --all или scrum-eye report --group <имя>
+portfolio:
+  concurrency: 4
+  groups: {}
+  # groups:
+  #   platform: ["payments", "accounts"]
 `
 	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 		return fmt.Errorf("не удалось создать global.yaml: %w", err)
@@ -41,9 +58,9 @@ description: "Опиши команду"
 azure:
   project: "YourProjectName"
   team: "%s"
-  board:
-    iterationPath: "YourProject\\%s"
-    areaPath: "YourProject\\%s"
+  maxWorkItems: 5000
+  # область (Area Path) команды; элементы спринта не фильтрует
+  areaPath: ""
   repos:
     - name: "your-repo-name"
       defaultBranch: "develop"
@@ -57,11 +74,25 @@ metrics:
   defaultBranch: "develop"
   wipLimit: 10
   wipPerPerson: 3
+  # что считать в WIP: пусто — каждую работу один раз (задачи истории в работе к ней не добавляются),
+  # backlog — только истории и баги, tasks — только задачи
+  wipLevel: ""
   overloadStoryPoints: 20
+  velocitySprints: 6
+  flowSprints: 3
+  forecastTrials: 10000
+
+mapping:
+  # шаблон процесса Azure DevOps: agile, scrum, cmmi или basic (пусто — все сразу)
+  process: ""
+  # дополнительные названия типов: <название в Azure> -> Story, Bug, Task, Epic, Feature, Impediment
+  types: {}
+  # состояния колонок: <состояние> -> Proposed, InProgress, Resolved, Completed, Removed
+  states: {}
 
 diff:
   baselineDays: 1
-`, teamName, teamName, teamName, teamName, teamName)
+`, teamName, teamName, teamName)
 
 	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 		return fmt.Errorf("не удалось создать конфиг команды %s: %w", teamName, err)
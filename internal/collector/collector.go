@@ -2,50 +2,237 @@ package collector
 
 import (
 	"context"
+	"fmt"
 	"scrum-eye/internal/domain"
-	"scrum-eye/internal/sources/azureboards"
+	"scrum-eye/internal/sources"
+	"time"
 )
 
 type Collector struct {
-	boards *azureboards.Client
+	boards sources.BoardsClient
+	ci     sources.CIClient
+	repos  sources.ReposClient
 	cfg    Config
 }
 
-func NewCollector(boards *azureboards.Client) *Collector {
-	return &Collector{boards: boards}
+func NewCollector(boards sources.BoardsClient, cfg Config) *Collector {
+	return &Collector{boards: boards, cfg: cfg}
+}
+
+// WithCI подключает источник сборок. Без него сборки не собираются.
+func (c *Collector) WithCI(ci sources.CIClient) *Collector {
+	c.ci = ci
+	return c
+}
+
+// WithRepos подключает источник пулл-реквестов. Без него пулл-реквесты не собираются.
+func (c *Collector) WithRepos(repos sources.ReposClient) *Collector {
+	c.repos = repos
+	return c
 }
 
 func (c *Collector) Collect(ctx context.Context) (*domain.Project, error) {
-	sprint, err := c.collectCurrentSprint(ctx)
+	project := &domain.Project{}
+
+	iterations, iteration, err := c.selectIteration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint, err := c.collectSprint(ctx, project, iteration)
 	if err != nil {
 		return nil, err
 	}
+	project.CurrentSprint = sprint
+
+	if c.cfg.HistorySprints > 0 {
+		history, err := c.collectHistory(ctx, project, iterations, iteration)
+		if err != nil {
+			return nil, err
+		}
+		project.SprintHistory = history
+	}
+
+	c.collectTransitions(ctx, project)
+
+	if c.ci != nil {
+		builds, err := c.collectBuilds(ctx)
+		if err != nil {
+			return nil, err
+		}
+		project.BuildConfigs = builds
+	}
 
-	project := &domain.Project{
-		CurrentSprint: sprint,
+	if c.repos != nil {
+		project.PullRequests = c.collectPullRequests(ctx, project)
 	}
 
 	return project, nil
 }
 
-func (c *Collector) collectCurrentSprint(ctx context.Context) (*domain.Sprint, error) {
-	iteration, err := c.boards.GetCurrentIteration(ctx)
+func (c *Collector) collectSprint(ctx context.Context, project *domain.Project,
+	iteration *sources.IterationDTO) (*domain.Sprint, error) {
+	workItems, err := c.boards.GetIterationWorkItems(ctx, iteration.ID)
 	if err != nil {
 		return nil, err
 	}
-
-	workItems, err := c.boards.GetIterationWorkItems(iteration.ID, ctx)
-	if err != nil {
-		return nil, err
+	if workItems.Truncated {
+		project.Warnings = append(project.Warnings, fmt.Sprintf(
+			"sprint %q has more than %d work items, only the first %d were loaded (azure.maxWorkItems)",
+			iteration.Name, workItems.Limit, workItems.Limit))
 	}
 
 	sprint := domain.Sprint{
 		ID:        iteration.ID,
 		Name:      iteration.Name,
-		StartDate: iteration.Attributes.StartDate,
-		EndDate:   iteration.Attributes.FinishDate,
-		WorkItems: MapODataWorkItems(*workItems),
+		Path:      iteration.Path,
+		TimeFrame: iteration.TimeFrame,
+		StartDate: iteration.StartDate,
+		EndDate:   iteration.FinishDate,
+		WorkItems: MapWorkItems(workItems.Items, c.cfg.Mapping),
 	}
 
 	return &sprint, nil
 }
+
+// selectIteration находит итерацию по c.cfg.Sprint. Список итераций запрашивается,
+// только если выбран не текущий спринт или нужна история; иначе возвращается nil.
+func (c *Collector) selectIteration(ctx context.Context) ([]sources.IterationDTO, *sources.IterationDTO, error) {
+	if c.cfg.Sprint.IsCurrent() && c.cfg.HistorySprints <= 0 {
+		iteration, err := c.boards.GetCurrentIteration(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if iteration.TimeFrame == "" {
+			iteration.TimeFrame = sources.TimeFrameCurrent
+		}
+		return nil, iteration, nil
+	}
+
+	iterations, err := c.boards.GetIterations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iteration, err := SelectIteration(iterations, c.cfg.Sprint, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return iterations, iteration, nil
+}
+
+// collectHistory собирает до c.cfg.HistorySprints завершённых спринтов,
+// предшествующих выбранному, от старых к новым.
+func (c *Collector) collectHistory(ctx context.Context, project *domain.Project,
+	iterations []sources.IterationDTO, selected *sources.IterationDTO) ([]domain.Sprint, error) {
+	past := PreviousIterations(iterations, selected, c.cfg.HistorySprints)
+
+	history := make([]domain.Sprint, 0, len(past))
+	for i := range past {
+		sprint, err := c.collectSprint(ctx, project, &past[i])
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, *sprint)
+	}
+
+	return history, nil
+}
+
+// collectTransitions загружает историю состояний элементов текущего спринта и последних
+// c.cfg.FlowSprints прошлых и определяет, когда и кем каждый элемент добавлен в свой спринт.
+// История не обязательна для отчёта: при ошибке добавляется предупреждение,
+// а анализ обходится датами самих элементов.
+func (c *Collector) collectTransitions(ctx context.Context, project *domain.Project) {
+	recent := domain.RecentSprints(project.SprintHistory, c.cfg.FlowSprints)
+	sprints := make([]*domain.Sprint, 0, len(recent)+1)
+	for i := range recent {
+		sprints = append(sprints, &recent[i])
+	}
+	sprints = append(sprints, project.CurrentSprint)
+
+	ids := make([]int, 0)
+	for _, s := range sprints {
+		for _, wi := range s.WorkItems {
+			ids = append(ids, wi.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	revisions, err := c.boards.GetWorkItemRevisions(ctx, ids)
+	if err != nil {
+		project.Warnings = append(project.Warnings, fmt.Sprintf(
+			"work item state history is unavailable (%v): flow, aging and CFD fall back to work item dates", err))
+		return
+	}
+
+	transitions := MapTransitions(revisions, c.cfg.Mapping)
+	byItem := map[int][]sources.WorkItemRevisionDTO{}
+	for _, r := range revisions {
+		byItem[r.WorkItemID] = append(byItem[r.WorkItemID], r)
+	}
+
+	for _, s := range sprints {
+		for i := range s.WorkItems {
+			wi := &s.WorkItems[i]
+			wi.Transitions = transitions[wi.ID]
+			wi.AddedToSprintAt, wi.AddedToSprintBy = SprintAddition(byItem[wi.ID], s.ID)
+		}
+		if s.StartDate != nil {
+			// в первый день спринта обычно идёт планирование: обязательства фиксируем к его концу
+			s.CommittedIDs = SprintCommitment(byItem, s.ID, s.StartDate.AddDate(0, 0, 1))
+		}
+	}
+}
+
+func (c *Collector) collectBuilds(ctx context.Context) ([]domain.BuildConfiguration, error) {
+	result := make([]domain.BuildConfiguration, 0, len(c.cfg.BuildConfigs))
+
+	for _, bc := range c.cfg.BuildConfigs {
+		builds, err := c.ci.GetBuilds(ctx, sources.BuildsQuery{
+			BuildConfigID: bc.ID,
+			Branch:        bc.Branch,
+			Count:         2 * c.cfg.MaxBuilds,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		name := bc.Name
+		if name == "" && len(builds) > 0 {
+			name = builds[0].BuildConfigName
+		}
+		if name == "" {
+			name = bc.ID
+		}
+
+		result = append(result, domain.BuildConfiguration{
+			ID:     bc.ID,
+			Name:   name,
+			Branch: bc.Branch,
+			Builds: MapBuilds(builds),
+		})
+	}
+
+	return result, nil
+}
+
+// collectPullRequests собирает пулл-реквесты репозиториев c.cfg.Repos. Метрики от них
+// не зависят, поэтому недоступный репозиторий даёт предупреждение, а не ошибку.
+func (c *Collector) collectPullRequests(ctx context.Context, project *domain.Project) []domain.PullRequest {
+	result := make([]domain.PullRequest, 0)
+
+	for _, repo := range c.cfg.Repos {
+		prs, err := c.repos.GetPullRequests(ctx, repo)
+		if err != nil {
+			project.Warnings = append(project.Warnings, fmt.Sprintf(
+				"pull requests of repository %q are unavailable (%v)", repo, err))
+			continue
+		}
+		result = append(result, MapPullRequests(prs)...)
+	}
+
+	return result
+}
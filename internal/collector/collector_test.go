@@ -0,0 +1,346 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"scrum-eye/internal/config"
+	"scrum-eye/internal/domain"
+	"scrum-eye/internal/sources"
+)
+
+// fakeBoards — трекер задач в памяти: итерации, их элементы и ревизии.
+type fakeBoards struct {
+	iterations   []sources.IterationDTO
+	items        map[string][]sources.WorkItemDTO
+	truncated    map[string]bool
+	revisions    []sources.WorkItemRevisionDTO
+	revisionsErr error
+
+	// revisionIDs — ID элементов, для которых запрашивались ревизии.
+	revisionIDs []int
+}
+
+func (f *fakeBoards) GetCurrentIteration(ctx context.Context) (*sources.IterationDTO, error) {
+	for _, it := range f.iterations {
+		if it.TimeFrame == sources.TimeFrameCurrent {
+			return &it, nil
+		}
+	}
+	return nil, errors.New("no current iteration")
+}
+
+func (f *fakeBoards) GetIterations(ctx context.Context) ([]sources.IterationDTO, error) {
+	return f.iterations, nil
+}
+
+func (f *fakeBoards) GetIterationWorkItems(ctx context.Context, iterationID string) (*sources.WorkItemsResult, error) {
+	items, ok := f.items[iterationID]
+	if !ok {
+		return nil, errors.New("unknown iteration " + iterationID)
+	}
+	result := &sources.WorkItemsResult{Items: items}
+	if f.truncated[iterationID] {
+		result.Truncated, result.Limit = true, len(items)
+	}
+	return result, nil
+}
+
+func (f *fakeBoards) GetWorkItemRevisions(ctx context.Context, ids []int) ([]sources.WorkItemRevisionDTO, error) {
+	f.revisionIDs = append([]int{}, ids...)
+	sort.Ints(f.revisionIDs)
+	if f.revisionsErr != nil {
+		return nil, f.revisionsErr
+	}
+	return f.revisions, nil
+}
+
+// fakeCI — источник сборок в памяти; запоминает запросы.
+type fakeCI struct {
+	builds  map[string][]sources.BuildDTO
+	queries []sources.BuildsQuery
+}
+
+func (f *fakeCI) GetBuilds(ctx context.Context, q sources.BuildsQuery) ([]sources.BuildDTO, error) {
+	f.queries = append(f.queries, q)
+	return f.builds[q.BuildConfigID], nil
+}
+
+// fakeRepos — пулл-реквесты репозиториев в памяти; репозитории из errs отвечают ошибкой.
+type fakeRepos struct {
+	prs  map[string][]sources.PullRequestDTO
+	errs map[string]error
+}
+
+func (f *fakeRepos) GetPullRequests(ctx context.Context, repository string) ([]sources.PullRequestDTO, error) {
+	if err := f.errs[repository]; err != nil {
+		return nil, err
+	}
+	return f.prs[repository], nil
+}
+
+func day(d int) *time.Time {
+	t := time.Date(2024, 3, d, 9, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func testMapping(t *testing.T) Mapping {
+	t.Helper()
+	m, err := NewMapping(config.MappingConfig{Process: "agile"})
+	if err != nil {
+		t.Fatalf("NewMapping: %v", err)
+	}
+	return m
+}
+
+// threeSprints — два прошлых спринта и текущий по две недели.
+func threeSprints() *fakeBoards {
+	return &fakeBoards{
+		iterations: []sources.IterationDTO{
+			{ID: "s1", Name: "Sprint 1", StartDate: day(1), FinishDate: day(14), TimeFrame: sources.TimeFramePast},
+			{ID: "s2", Name: "Sprint 2", StartDate: day(15), FinishDate: day(28), TimeFrame: sources.TimeFramePast},
+			{ID: "s3", Name: "Sprint 3", StartDate: day(29), FinishDate: nil, TimeFrame: sources.TimeFrameCurrent},
+		},
+		items: map[string][]sources.WorkItemDTO{
+			"s1": {{ID: 1, Title: "old story", Type: "User Story", State: "Closed", StoryPoints: 3}},
+			"s2": {{ID: 2, Title: "bug", Type: "Bug", State: "Resolved", Effort: 2}},
+			"s3": {
+				{ID: 3, Title: "story", Type: "User Story", State: "Active", StoryPoints: 5},
+				{ID: 4, Title: "task", Type: "Task", State: "New", ParentID: 3},
+			},
+		},
+	}
+}
+
+func TestCollectCurrentSprint(t *testing.T) {
+	boards := threeSprints()
+	boards.truncated = map[string]bool{"s3": true}
+
+	project, err := NewCollector(boards, Config{Mapping: testMapping(t)}).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	sprint := project.CurrentSprint
+	if sprint == nil || sprint.ID != "s3" || sprint.Name != "Sprint 3" {
+		t.Fatalf("CurrentSprint = %+v, want Sprint 3", sprint)
+	}
+	if len(project.SprintHistory) != 0 {
+		t.Errorf("SprintHistory = %d sprints, want none without metrics.velocitySprints", len(project.SprintHistory))
+	}
+	if len(sprint.WorkItems) != 2 {
+		t.Fatalf("WorkItems = %d, want 2", len(sprint.WorkItems))
+	}
+
+	story, task := sprint.WorkItems[0], sprint.WorkItems[1]
+	if story.Type != domain.WorkItemStory || story.StateCategory != domain.StateInProgress || story.StoryPoints != 5 {
+		t.Errorf("story = %s/%s/%v, want Story/InProgress/5", story.Type, story.StateCategory, story.StoryPoints)
+	}
+	if task.Type != domain.WorkItemTask || task.StateCategory != domain.StateProposed || task.ParentID != 3 {
+		t.Errorf("task = %s/%s parent %d, want Task/Proposed parent 3", task.Type, task.StateCategory, task.ParentID)
+	}
+
+	if len(project.Warnings) != 1 || !strings.Contains(project.Warnings[0], "azure.maxWorkItems") {
+		t.Errorf("Warnings = %q, want a truncation warning", project.Warnings)
+	}
+}
+
+func TestCollectHistoryAndFlowWindow(t *testing.T) {
+	boards := threeSprints()
+	cfg := Config{Mapping: testMapping(t), HistorySprints: 2, FlowSprints: 1}
+
+	project, err := NewCollector(boards, cfg).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	var names []string
+	for _, s := range project.SprintHistory {
+		names = append(names, s.Name)
+	}
+	if want := []string{"Sprint 1", "Sprint 2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("SprintHistory = %q, want %q (oldest first)", names, want)
+	}
+	if bug := project.SprintHistory[1].WorkItems[0]; bug.StoryPoints != 2 {
+		t.Errorf("bug StoryPoints = %v, want Effort 2 as a fallback", bug.StoryPoints)
+	}
+
+	// история состояний загружается только для последнего прошлого спринта и текущего
+	if want := []int{2, 3, 4}; !reflect.DeepEqual(boards.revisionIDs, want) {
+		t.Errorf("revisions requested for %v, want %v", boards.revisionIDs, want)
+	}
+}
+
+func TestCollectTransitionsAndCommitment(t *testing.T) {
+	boards := threeSprints()
+	boards.revisions = []sources.WorkItemRevisionDTO{
+		{WorkItemID: 3, Revision: 1, State: "New", IterationID: "backlog", ChangedBy: "Ann", ChangedDate: day(20)},
+		{WorkItemID: 3, Revision: 2, State: "New", IterationID: "s3", ChangedBy: "Bob", ChangedDate: day(29)},
+		{WorkItemID: 3, Revision: 3, State: "Active", IterationID: "s3", ChangedBy: "Bob", ChangedDate: day(31)},
+		{WorkItemID: 4, Revision: 1, State: "New", IterationID: "s3", ChangedBy: "Eve", ChangedDate: day(31)},
+	}
+
+	project, err := NewCollector(boards, Config{Mapping: testMapping(t)}).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	sprint := project.CurrentSprint
+	story, task := sprint.WorkItems[0], sprint.WorkItems[1]
+
+	if len(story.Transitions) != 2 {
+		t.Fatalf("story transitions = %+v, want New and Active", story.Transitions)
+	}
+	if tr := story.Transitions[1]; tr.From != "New" || tr.To != "Active" || tr.Category != domain.StateInProgress || !tr.At.Equal(*day(31)) {
+		t.Errorf("story transition = %+v, want New → Active on day 31", tr)
+	}
+
+	if story.AddedToSprintAt == nil || !story.AddedToSprintAt.Equal(*day(29)) || story.AddedToSprintBy != "Bob" {
+		t.Errorf("story added %v by %q, want day 29 by Bob", story.AddedToSprintAt, story.AddedToSprintBy)
+	}
+	if task.AddedToSprintBy != "Eve" {
+		t.Errorf("task added by %q, want Eve", task.AddedToSprintBy)
+	}
+
+	// задача добавлена после первого дня спринта и в обязательства не входит
+	if want := []int{3}; !reflect.DeepEqual(sprint.CommittedIDs, want) {
+		t.Errorf("CommittedIDs = %v, want %v", sprint.CommittedIDs, want)
+	}
+	if len(project.Warnings) != 0 {
+		t.Errorf("Warnings = %q, want none", project.Warnings)
+	}
+}
+
+func TestCollectWithoutRevisions(t *testing.T) {
+	boards := threeSprints()
+	boards.revisionsErr = errors.New("403 Forbidden")
+
+	project, err := NewCollector(boards, Config{Mapping: testMapping(t)}).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v, want the report to survive a revisions failure", err)
+	}
+
+	if len(project.Warnings) != 1 || !strings.Contains(project.Warnings[0], "403 Forbidden") {
+		t.Errorf("Warnings = %q, want the revisions error", project.Warnings)
+	}
+	for _, wi := range project.CurrentSprint.WorkItems {
+		if wi.Transitions != nil || wi.AddedToSprintAt != nil {
+			t.Errorf("item %d has history without revisions", wi.ID)
+		}
+	}
+}
+
+func TestCollectBuilds(t *testing.T) {
+	ci := &fakeCI{builds: map[string][]sources.BuildDTO{
+		"Web_Build": {
+			{ID: "2", BuildConfigName: "Web build", Status: "SUCCESS"},
+			{ID: "1", BuildConfigName: "Web build", Status: "FAILURE"},
+		},
+	}}
+	cfg := Config{
+		Mapping:   testMapping(t),
+		MaxBuilds: 10,
+		BuildConfigs: []BuildConfig{
+			{ID: "Web_Build", Branch: "main"},
+			{ID: "Api_Build", Name: "API"},
+			{ID: "Docs_Build"},
+		},
+	}
+
+	project, err := NewCollector(threeSprints(), cfg).WithCI(ci).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	want := []sources.BuildsQuery{
+		{BuildConfigID: "Web_Build", Branch: "main", Count: 20},
+		{BuildConfigID: "Api_Build", Count: 20},
+		{BuildConfigID: "Docs_Build", Count: 20},
+	}
+	if !reflect.DeepEqual(ci.queries, want) {
+		t.Errorf("queries = %+v, want %+v", ci.queries, want)
+	}
+
+	var names []string
+	for _, bc := range project.BuildConfigs {
+		names = append(names, bc.Name)
+	}
+	if want := []string{"Web build", "API", "Docs_Build"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("build config names = %q, want %q", names, want)
+	}
+
+	web := project.BuildConfigs[0]
+	if web.Branch != "main" || len(web.Builds) != 2 ||
+		web.Builds[0].Status != domain.BuildSuccess || web.Builds[1].Status != domain.BuildFailure {
+		t.Errorf("Web_Build = %+v, want two mapped builds on main", web)
+	}
+}
+
+func TestCollectWithoutCI(t *testing.T) {
+	cfg := Config{Mapping: testMapping(t), BuildConfigs: []BuildConfig{{ID: "Web_Build"}}}
+
+	project, err := NewCollector(threeSprints(), cfg).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if project.BuildConfigs != nil {
+		t.Errorf("BuildConfigs = %+v, want none without a CI client", project.BuildConfigs)
+	}
+}
+
+func TestCollectPullRequests(t *testing.T) {
+	repos := &fakeRepos{
+		prs: map[string][]sources.PullRequestDTO{
+			"web": {{ID: 7, Title: "Add login", Repository: "web", SourceBranch: "refs/heads/feature/login",
+				TargetBranch: "refs/heads/develop", Status: "active", CreatedBy: "Ann", CreationDate: day(30)}},
+			"api": {{ID: 3, Title: "Fix paging", Repository: "api", SourceBranch: "refs/heads/fix",
+				TargetBranch: "refs/heads/main", Status: "completed", ClosedDate: day(31)}},
+		},
+		errs: map[string]error{"docs": errors.New("404 Not Found")},
+	}
+	cfg := Config{Mapping: testMapping(t), Repos: []string{"web", "docs", "api"}}
+
+	project, err := NewCollector(threeSprints(), cfg).WithRepos(repos).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v, want the report to survive an unavailable repository", err)
+	}
+
+	want := []domain.PullRequest{
+		{ID: 7, Title: "Add login", Repository: "web", SourceBranch: "feature/login",
+			TargetBranch: "develop", Status: "active", CreatedBy: "Ann", CreatedAt: day(30)},
+		{ID: 3, Title: "Fix paging", Repository: "api", SourceBranch: "fix",
+			TargetBranch: "main", Status: "completed", ClosedAt: day(31)},
+	}
+	if !reflect.DeepEqual(project.PullRequests, want) {
+		t.Errorf("PullRequests = %+v, want %+v", project.PullRequests, want)
+	}
+	if len(project.Warnings) != 1 || !strings.Contains(project.Warnings[0], `"docs"`) {
+		t.Errorf("Warnings = %q, want the docs repository failure", project.Warnings)
+	}
+}
+
+func TestCollectWithoutRepos(t *testing.T) {
+	cfg := Config{Mapping: testMapping(t), Repos: []string{"web"}}
+
+	project, err := NewCollector(threeSprints(), cfg).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if project.PullRequests != nil {
+		t.Errorf("PullRequests = %+v, want none without a repos client", project.PullRequests)
+	}
+}
+
+func TestCollectUnknownSprint(t *testing.T) {
+	cfg := Config{Mapping: testMapping(t), Sprint: SprintSelector{Name: "Sprint 9"}}
+
+	_, err := NewCollector(threeSprints(), cfg).Collect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Sprint 9") {
+		t.Errorf("err = %v, want the unknown sprint to be reported", err)
+	}
+}
@@ -1,4 +1,69 @@
 package collector
 
+import "scrum-eye/internal/config"
+
+// BuildConfig — конфигурация сборки, по которой собирается история.
+type BuildConfig struct {
+	ID     string
+	Name   string
+	Branch string
+}
+
+// defaultMaxBuilds — размер окна сборок, если metrics.maxBuilds не задан.
+const defaultMaxBuilds = 20
+
+// DefaultFlowSprints — сколько прошлых спринтов учитывать в cycle time, если metrics.flowSprints не задан.
+const DefaultFlowSprints = 3
+
 type Config struct {
+	BuildConfigs []BuildConfig
+	// MaxBuilds — размер окна анализа сборок. Собирается вдвое больше,
+	// чтобы было с чем сравнить тренд длительности.
+	MaxBuilds int
+	Mapping   Mapping
+	Sprint    SprintSelector
+	// HistorySprints — сколько предыдущих спринтов собирать для расчёта скорости.
+	HistorySprints int
+	// FlowSprints — для скольких последних спринтов истории загружать историю состояний.
+	FlowSprints int
+	// Repos — репозитории, пулл-реквесты которых попадают в отчёт.
+	Repos []string
+}
+
+// NewConfig строит настройки сборщика из конфигурации команды.
+// Ветка конфигурации сборки по умолчанию берётся из metrics.defaultBranch.
+func NewConfig(team config.TeamConfig) (Config, error) {
+	mapping, err := NewMapping(team.Mapping)
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg := Config{
+		MaxBuilds:      team.Metrics.MaxBuilds,
+		Mapping:        mapping,
+		HistorySprints: team.Metrics.VelocitySprints,
+		FlowSprints:    team.Metrics.FlowSprints,
+	}
+	if cfg.MaxBuilds <= 0 {
+		cfg.MaxBuilds = defaultMaxBuilds
+	}
+	if cfg.FlowSprints <= 0 {
+		cfg.FlowSprints = DefaultFlowSprints
+	}
+
+	for _, bc := range team.TeamCity.BuildConfigs {
+		branch := bc.Branch
+		if branch == "" {
+			branch = team.Metrics.DefaultBranch
+		}
+		cfg.BuildConfigs = append(cfg.BuildConfigs, BuildConfig{ID: bc.ID, Name: bc.Name, Branch: branch})
+	}
+
+	for _, repo := range team.AzureDevOps.Repos {
+		if repo.Name != "" {
+			cfg.Repos = append(cfg.Repos, repo.Name)
+		}
+	}
+
+	return cfg, nil
 }
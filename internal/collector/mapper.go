@@ -2,18 +2,43 @@ package collector
 
 import (
 	"scrum-eye/internal/domain"
-	"scrum-eye/internal/sources/azureboards"
+	"scrum-eye/internal/sources"
+	"sort"
 	"strings"
+	"time"
 )
 
-func MapODataWorkItems(src []azureboards.ODataWorkItem) []domain.WorkItem {
+func MapWorkItems(src []sources.WorkItemDTO, mapping Mapping) []domain.WorkItem {
 	dst := make([]domain.WorkItem, 0, len(src))
 
 	for _, v := range src {
 		wi := domain.WorkItem{
-			ID:   v.ID,
-			Name: v.Title,
-			Type: normalizeWorkItemType(v.WorkItemType),
+			ID:               v.ID,
+			Name:             v.Title,
+			Type:             mapping.WorkItemType(v.Type),
+			URL:              v.WebURL,
+			State:            v.State,
+			StateCategory:    mapping.StateCategory(v.State, v.StateCategory),
+			AssignedTo:       v.AssignedTo,
+			StoryPoints:      v.StoryPoints,
+			OriginalEstimate: v.OriginalEstimate,
+			RemainingWork:    v.RemainingWork,
+			CompletedWork:    v.CompletedWork,
+			Priority:         v.Priority,
+			Severity:         v.Severity,
+			ParentID:         v.ParentID,
+			Tags:             v.Tags,
+			AreaPath:         v.AreaPath,
+			CreatedDate:      v.CreatedDate,
+			ActivatedDate:    v.ActivatedDate,
+			ResolvedDate:     v.ResolvedDate,
+			ClosedDate:       v.ClosedDate,
+			ChangedDate:      v.ChangedDate,
+			StateChangeDate:  v.StateChangeDate,
+		}
+
+		if wi.StoryPoints == 0 {
+			wi.StoryPoints = v.Effort
 		}
 
 		dst = append(dst, wi)
@@ -22,19 +47,135 @@ func MapODataWorkItems(src []azureboards.ODataWorkItem) []domain.WorkItem {
 	return dst
 }
 
-func normalizeWorkItemType(t string) domain.WorkItemType {
-	switch strings.ToLower(t) {
-	case "user story":
-		return domain.WorkItemStory
-	case "bug":
-		return domain.WorkItemBug
-	case "task":
-		return domain.WorkItemTask
-	case "epic":
-		return domain.WorkItemEpic
-	case "feature":
-		return domain.WorkItemFeature
+// MapTransitions строит историю смены состояний каждого элемента по его ревизиям.
+// Ревизии должны быть упорядочены по элементу и номеру ревизии; ревизии без смены состояния пропускаются.
+func MapTransitions(src []sources.WorkItemRevisionDTO, mapping Mapping) map[int][]domain.StateTransition {
+	dst := make(map[int][]domain.StateTransition)
+
+	prev := map[int]string{}
+	for _, v := range src {
+		state, seen := prev[v.WorkItemID]
+		if (seen && state == v.State) || v.ChangedDate == nil {
+			continue
+		}
+		prev[v.WorkItemID] = v.State
+
+		dst[v.WorkItemID] = append(dst[v.WorkItemID], domain.StateTransition{
+			At:       *v.ChangedDate,
+			From:     state,
+			To:       v.State,
+			Category: mapping.StateCategory(v.State, v.StateCategory),
+			By:       v.ChangedBy,
+		})
+	}
+
+	return dst
+}
+
+// SprintAddition находит ревизию, которой элемент последний раз попал в итерацию iterationID.
+// Ревизии одного элемента должны быть упорядочены по номеру.
+func SprintAddition(revisions []sources.WorkItemRevisionDTO, iterationID string) (*time.Time, string) {
+	var at *time.Time
+	var by string
+
+	prev := ""
+	for _, v := range revisions {
+		if strings.EqualFold(v.IterationID, iterationID) && !strings.EqualFold(prev, iterationID) {
+			at, by = v.ChangedDate, v.ChangedBy
+		}
+		prev = v.IterationID
+	}
+
+	return at, by
+}
+
+// SprintCommitment возвращает ID элементов, которые по ревизиям находились в итерации
+// iterationID на момент at. Элементы, ушедшие в итерации без загруженных ревизий, не видны.
+func SprintCommitment(revisions map[int][]sources.WorkItemRevisionDTO, iterationID string, at time.Time) []int {
+	ids := make([]int, 0)
+	for id, revs := range revisions {
+		iteration := ""
+		for _, v := range revs {
+			if v.ChangedDate != nil && v.ChangedDate.After(at) {
+				break
+			}
+			iteration = v.IterationID
+		}
+		if iteration != "" && strings.EqualFold(iteration, iterationID) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Ints(ids)
+	return ids
+}
+
+func MapBuilds(src []sources.BuildDTO) []domain.Build {
+	dst := make([]domain.Build, 0, len(src))
+
+	for _, v := range src {
+		b := domain.Build{
+			ID:          v.ID,
+			Number:      v.Number,
+			Branch:      v.Branch,
+			Status:      normalizeBuildStatus(v.Status),
+			StatusText:  v.StatusText,
+			WebURL:      v.WebURL,
+			QueuedAt:    v.QueuedDate,
+			StartedAt:   v.StartDate,
+			FinishedAt:  v.FinishDate,
+			TriggeredBy: v.TriggeredBy,
+			Changes:     v.ChangesCount,
+			Tests: domain.TestCounts{
+				Total:   v.TestsTotal,
+				Passed:  v.TestsPassed,
+				Failed:  v.TestsFailed,
+				Ignored: v.TestsIgnored,
+				Muted:   v.TestsMuted,
+			},
+		}
+
+		if v.StartDate != nil && v.FinishDate != nil {
+			b.Duration = v.FinishDate.Sub(*v.StartDate)
+		}
+		if v.QueuedDate != nil && v.StartDate != nil {
+			b.QueueTime = v.StartDate.Sub(*v.QueuedDate)
+		}
+
+		dst = append(dst, b)
+	}
+
+	return dst
+}
+
+func normalizeBuildStatus(s string) domain.BuildStatus {
+	switch strings.ToUpper(s) {
+	case "SUCCESS":
+		return domain.BuildSuccess
+	case "FAILURE", "ERROR":
+		return domain.BuildFailure
 	default:
-		return domain.WorkItemUnknown
+		return domain.BuildUnknown
+	}
+}
+
+func MapPullRequests(src []sources.PullRequestDTO) []domain.PullRequest {
+	dst := make([]domain.PullRequest, 0, len(src))
+
+	for _, v := range src {
+		dst = append(dst, domain.PullRequest{
+			ID:           v.ID,
+			Title:        v.Title,
+			Repository:   v.Repository,
+			SourceBranch: strings.TrimPrefix(v.SourceBranch, "refs/heads/"),
+			TargetBranch: strings.TrimPrefix(v.TargetBranch, "refs/heads/"),
+			Status:       v.Status,
+			CreatedBy:    v.CreatedBy,
+			CreatedAt:    v.CreationDate,
+			ClosedAt:     v.ClosedDate,
+			IsDraft:      v.IsDraft,
+		})
 	}
+
+	return dst
 }
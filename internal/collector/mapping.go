@@ -0,0 +1,225 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"scrum-eye/internal/config"
+	"scrum-eye/internal/domain"
+)
+
+// Процессы Azure DevOps, для которых есть встроенные соответствия.
+const (
+	ProcessAgile = "agile"
+	ProcessScrum = "scrum"
+	ProcessCMMI  = "cmmi"
+	ProcessBasic = "basic"
+)
+
+// defaultTypes — встроенные соответствия типов, общие для всех процессов (ключи в нижнем регистре).
+var defaultTypes = map[string]domain.WorkItemType{
+	"user story":           domain.WorkItemStory,
+	"product backlog item": domain.WorkItemStory,
+	"requirement":          domain.WorkItemStory,
+	"bug":                  domain.WorkItemBug,
+	"task":                 domain.WorkItemTask,
+	"epic":                 domain.WorkItemEpic,
+	"feature":              domain.WorkItemFeature,
+	"impediment":           domain.WorkItemImpediment,
+	"issue":                domain.WorkItemImpediment,
+}
+
+// localizedTypes — русские названия типов локализованных процессов.
+var localizedTypes = map[string]domain.WorkItemType{
+	"пользовательская история":                 domain.WorkItemStory,
+	"элемент невыполненной работы по продукту": domain.WorkItemStory,
+	"требование":  domain.WorkItemStory,
+	"ошибка":      domain.WorkItemBug,
+	"задача":      domain.WorkItemTask,
+	"эпик":        domain.WorkItemEpic,
+	"компонент":   domain.WorkItemFeature,
+	"функция":     domain.WorkItemFeature,
+	"препятствие": domain.WorkItemImpediment,
+	"проблема":    domain.WorkItemImpediment,
+}
+
+// processTypes — соответствия типов, которые отличаются между процессами.
+var processTypes = map[string]map[string]domain.WorkItemType{
+	// в Basic «Issue» — это элемент бэклога, а не препятствие
+	ProcessBasic: {
+		"issue":    domain.WorkItemStory,
+		"проблема": domain.WorkItemStory,
+	},
+}
+
+// defaultStates — встроенные соответствия состояний категориям по шаблонам процессов.
+var defaultStates = map[string]map[string]domain.StateCategory{
+	ProcessAgile: {
+		"new":      domain.StateProposed,
+		"active":   domain.StateInProgress,
+		"resolved": domain.StateResolved,
+		"closed":   domain.StateCompleted,
+		"removed":  domain.StateRemoved,
+	},
+	ProcessScrum: {
+		"new":         domain.StateProposed,
+		"approved":    domain.StateProposed,
+		"to do":       domain.StateProposed,
+		"committed":   domain.StateInProgress,
+		"in progress": domain.StateInProgress,
+		"open":        domain.StateInProgress,
+		"done":        domain.StateCompleted,
+		"closed":      domain.StateCompleted,
+		"removed":     domain.StateRemoved,
+	},
+	ProcessCMMI: {
+		"proposed": domain.StateProposed,
+		"active":   domain.StateInProgress,
+		"resolved": domain.StateResolved,
+		"closed":   domain.StateCompleted,
+		"removed":  domain.StateRemoved,
+	},
+	ProcessBasic: {
+		"to do": domain.StateProposed,
+		"doing": domain.StateInProgress,
+		"done":  domain.StateCompleted,
+	},
+}
+
+// localizedStates — русские названия состояний локализованных процессов.
+var localizedStates = map[string]domain.StateCategory{
+	"новый":         domain.StateProposed,
+	"новая":         domain.StateProposed,
+	"предложено":    domain.StateProposed,
+	"утверждено":    domain.StateProposed,
+	"к выполнению":  domain.StateProposed,
+	"активно":       domain.StateInProgress,
+	"активный":      domain.StateInProgress,
+	"выполняется":   domain.StateInProgress,
+	"в работе":      domain.StateInProgress,
+	"зафиксировано": domain.StateInProgress,
+	"решено":        domain.StateResolved,
+	"закрыто":       domain.StateCompleted,
+	"готово":        domain.StateCompleted,
+	"выполнено":     domain.StateCompleted,
+	"удалено":       domain.StateRemoved,
+}
+
+// Mapping переводит названия типов и состояний конкретного процесса в доменные.
+type Mapping struct {
+	types  map[string]domain.WorkItemType
+	states map[string]domain.StateCategory
+	// stateOverrides — соответствия состояний из конфига команды.
+	stateOverrides map[string]domain.StateCategory
+}
+
+// NewMapping собирает соответствия: встроенные для процесса mapping.process
+// (или для всех процессов, если он не указан), поверх них — заданные в конфиге команды.
+func NewMapping(cfg config.MappingConfig) (Mapping, error) {
+	process := strings.ToLower(strings.TrimSpace(cfg.Process))
+	if _, ok := defaultStates[process]; process != "" && !ok {
+		return Mapping{}, fmt.Errorf("mapping.process: неизвестный процесс %q (agile, scrum, cmmi, basic)", cfg.Process)
+	}
+
+	m := Mapping{
+		types:          map[string]domain.WorkItemType{},
+		states:         map[string]domain.StateCategory{},
+		stateOverrides: map[string]domain.StateCategory{},
+	}
+
+	for k, v := range defaultTypes {
+		m.types[k] = v
+	}
+	for k, v := range localizedTypes {
+		m.types[k] = v
+	}
+	for k, v := range processTypes[process] {
+		m.types[k] = v
+	}
+
+	for p, states := range defaultStates {
+		if process != "" && p != process {
+			continue
+		}
+		for k, v := range states {
+			m.states[k] = v
+		}
+	}
+	for k, v := range localizedStates {
+		m.states[k] = v
+	}
+
+	for alias, name := range cfg.Types {
+		t, ok := parseWorkItemType(name)
+		if !ok {
+			return Mapping{}, fmt.Errorf("mapping.types.%s: неизвестный тип %q", alias, name)
+		}
+		m.types[strings.ToLower(alias)] = t
+	}
+
+	for state, name := range cfg.States {
+		c, ok := parseStateCategory(name)
+		if !ok {
+			return Mapping{}, fmt.Errorf("mapping.states.%s: неизвестная категория %q", state, name)
+		}
+		m.stateOverrides[strings.ToLower(state)] = c
+	}
+
+	return m, nil
+}
+
+// WorkItemType возвращает доменный тип по названию типа в трекере.
+func (m Mapping) WorkItemType(t string) domain.WorkItemType {
+	if v, ok := m.types[strings.ToLower(strings.TrimSpace(t))]; ok {
+		return v
+	}
+	return domain.WorkItemUnknown
+}
+
+// StateCategory определяет категорию состояния. Явное соответствие из конфига важнее
+// категории, которую отдаёт источник, а встроенные соответствия используются как запасной вариант.
+func (m Mapping) StateCategory(state, sourceCategory string) domain.StateCategory {
+	key := strings.ToLower(strings.TrimSpace(state))
+
+	if c, ok := m.stateOverrides[key]; ok {
+		return c
+	}
+	if c, ok := parseStateCategory(sourceCategory); ok {
+		return c
+	}
+	if c, ok := m.states[key]; ok {
+		return c
+	}
+	return domain.StateUnknown
+}
+
+func parseWorkItemType(s string) (domain.WorkItemType, bool) {
+	for _, t := range []domain.WorkItemType{
+		domain.WorkItemStory,
+		domain.WorkItemBug,
+		domain.WorkItemTask,
+		domain.WorkItemEpic,
+		domain.WorkItemFeature,
+		domain.WorkItemImpediment,
+	} {
+		if strings.EqualFold(s, string(t)) {
+			return t, true
+		}
+	}
+	return domain.WorkItemUnknown, false
+}
+
+func parseStateCategory(s string) (domain.StateCategory, bool) {
+	for _, c := range []domain.StateCategory{
+		domain.StateProposed,
+		domain.StateInProgress,
+		domain.StateResolved,
+		domain.StateCompleted,
+		domain.StateRemoved,
+	} {
+		if strings.EqualFold(s, string(c)) {
+			return c, true
+		}
+	}
+	return domain.StateUnknown, false
+}
@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"scrum-eye/internal/sources"
+)
+
+// Ключевые слова --sprint.
+const (
+	SprintCurrent  = "current"
+	SprintPrevious = "previous"
+	SprintNext     = "next"
+)
+
+// SprintSelector описывает, какой спринт собирать: по имени или пути итерации,
+// либо ключевым словом (current, previous, next), со сдвигом Offset в спринтах.
+// Пустой селектор означает текущий спринт.
+type SprintSelector struct {
+	Name   string
+	Offset int
+}
+
+// IsCurrent — селектор указывает на текущий спринт без сдвига.
+func (s SprintSelector) IsCurrent() bool {
+	name := strings.ToLower(s.Name)
+	return (name == "" || name == SprintCurrent) && s.Offset == 0
+}
+
+func (s SprintSelector) String() string {
+	name := s.Name
+	if name == "" {
+		name = SprintCurrent
+	}
+	if s.Offset == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s%+d", name, s.Offset)
+}
+
+// SelectIteration выбирает итерацию из списка, отсортированного по дате начала.
+func SelectIteration(iterations []sources.IterationDTO, sel SprintSelector, now time.Time) (*sources.IterationDTO, error) {
+	if len(iterations) == 0 {
+		return nil, fmt.Errorf("у команды нет итераций")
+	}
+
+	offset := sel.Offset
+	var idx int
+
+	switch name := strings.ToLower(strings.TrimSpace(sel.Name)); name {
+	case "", SprintCurrent, SprintPrevious, SprintNext:
+		idx = currentIterationIndex(iterations, now)
+		if idx < 0 {
+			return nil, fmt.Errorf("не удалось определить текущую итерацию")
+		}
+		if name == SprintPrevious {
+			offset--
+		}
+		if name == SprintNext {
+			offset++
+		}
+	default:
+		idx = findIteration(iterations, sel.Name)
+		if idx < 0 {
+			return nil, fmt.Errorf("итерация %q не найдена", sel.Name)
+		}
+	}
+
+	target := idx + offset
+	if target < 0 || target >= len(iterations) {
+		return nil, fmt.Errorf("итерации со сдвигом %s нет: у команды %d итераций", sel, len(iterations))
+	}
+
+	it := iterations[target]
+	return &it, nil
+}
+
+// currentIterationIndex ищет итерацию с timeFrame=current, а если её нет — по датам.
+func currentIterationIndex(iterations []sources.IterationDTO, now time.Time) int {
+	for i, it := range iterations {
+		if it.TimeFrame == sources.TimeFrameCurrent {
+			return i
+		}
+	}
+	for i, it := range iterations {
+		if it.StartDate != nil && it.FinishDate != nil &&
+			!now.Before(*it.StartDate) && !now.After(it.FinishDate.AddDate(0, 0, 1)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findIteration ищет итерацию по имени, полному пути или окончанию пути.
+func findIteration(iterations []sources.IterationDTO, name string) int {
+	name = strings.TrimSpace(name)
+	for i, it := range iterations {
+		if strings.EqualFold(it.Name, name) || strings.EqualFold(it.Path, name) {
+			return i
+		}
+	}
+	for i, it := range iterations {
+		if strings.HasSuffix(strings.ToLower(it.Path), "\\"+strings.ToLower(name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// PreviousIterations возвращает до n завершённых итераций перед selected, от старых к новым.
+func PreviousIterations(iterations []sources.IterationDTO, selected *sources.IterationDTO, n int) []sources.IterationDTO {
+	idx := -1
+	for i, it := range iterations {
+		if it.ID == selected.ID {
+			idx = i
+			break
+		}
+	}
+	if idx <= 0 {
+		return nil
+	}
+
+	result := make([]sources.IterationDTO, 0, n)
+	for i := idx - 1; i >= 0 && len(result) < n; i-- {
+		if iterations[i].TimeFrame == sources.TimeFrameFuture || iterations[i].StartDate == nil {
+			continue
+		}
+		result = append(result, iterations[i])
+	}
+
+	// разворачиваем: от старых к новым
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
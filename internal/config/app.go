@@ -3,4 +3,6 @@ package config
 type AppConfig struct {
 	Global GlobalConfig
 	Team   TeamConfig
+	// Warnings — некритичные замечания к конфигам, например устаревшие поля.
+	Warnings []Issue
 }
@@ -3,8 +3,48 @@ package config
 type AzureDevOpsConfig struct {
 	Organization string `yaml:"organization"`
 	Token        string `yaml:"token"`
+	TokenFile    string `yaml:"tokenFile"`
+}
+
+type AuthConfig struct {
+	AzurePat          string `yaml:"azurePat"`
+	AzurePatFile      string `yaml:"azurePatFile"`
+	TeamCityToken     string `yaml:"teamcityToken"`
+	TeamCityTokenFile string `yaml:"teamcityTokenFile"`
+	// CredentialHelper — команда в стиле git credential helper, у которой
+	// запрашиваются токены, не найденные в конфигах.
+	CredentialHelper string `yaml:"credentialHelper"`
+}
+
+type TeamCityConfig struct {
+	BaseURL string `yaml:"baseUrl"`
+}
+
+type DefaultsConfig struct {
+	Branch     string `yaml:"branch"`
+	MaxBuilds  int    `yaml:"maxBuilds"`
+	SprintMode string `yaml:"sprintMode"`
+}
+
+type StorageConfig struct {
+	Path          string `yaml:"path"`
+	RetentionDays int    `yaml:"retentionDays"`
+	MaxSnapshots  int    `yaml:"maxSnapshots"`
+}
+
+// PortfolioConfig — отчёт сразу по нескольким командам (report --all, report --group).
+type PortfolioConfig struct {
+	// Concurrency — сколько команд собирается одновременно; 0 — значение по умолчанию.
+	Concurrency int `yaml:"concurrency"`
+	// Groups — именованные группы команд: имя группы → имена конфигов из teams.
+	Groups map[string][]string `yaml:"groups"`
 }
 
 type GlobalConfig struct {
 	AzureDevOps AzureDevOpsConfig `yaml:"azure"`
+	Auth        AuthConfig        `yaml:"auth"`
+	TeamCity    TeamCityConfig    `yaml:"teamcity"`
+	Storage     StorageConfig     `yaml:"storage"`
+	Defaults    DefaultsConfig    `yaml:"defaults"`
+	Portfolio   PortfolioConfig   `yaml:"portfolio"`
 }
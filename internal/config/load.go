@@ -2,41 +2,80 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
-
-	"gopkg.in/yaml.v3"
 )
 
+// Load читает global.yaml и конфиг команды, сливает их и проверяет результат.
+// Неизвестные поля, ошибки типов и незаполненные обязательные поля возвращаются
+// одной ошибкой *ValidationError с указанием файла, строки и колонки.
+// Устаревшие поля ошибкой не считаются: их значения переносятся, а замечания попадают в Warnings.
 func Load(globalPath, teamsDir, teamName string) (*AppConfig, error) {
 	var g GlobalConfig
-	if err := loadYAML(globalPath, &g); err != nil {
+	globalFile, issues, err := decodeStrict(globalPath, &g)
+	if err != nil {
 		return nil, fmt.Errorf("load global: %w", err)
 	}
 
 	teamPath := filepath.Join(teamsDir, teamName+".yaml")
 
 	var t TeamConfig
-	if err := loadYAML(teamPath, &t); err != nil {
+	teamFile, teamIssues, err := decodeStrict(teamPath, &t)
+	if err != nil {
 		return nil, fmt.Errorf("load team %s: %w", teamName, err)
 	}
+	issues = append(issues, teamIssues...)
+
+	var warnings []Issue
+
+	// обязательные поля и секреты проверяем, только если файлы вообще удалось разобрать
+	if globalFile != nil && teamFile != nil {
+		issues = append(issues, readSecretFiles(globalFile, globalSecrets(&g))...)
+		issues = append(issues, readSecretFiles(teamFile, teamSecrets(&t))...)
+		issues = append(issues, checkPortfolio(g.Portfolio, globalFile)...)
+		warnings = migrateDeprecated(&t, teamFile)
 
-	t = *merge(g, t)
+		t = *merge(g, t)
+
+		issues = append(issues, fillFromCredentialHelper(&t, g.Auth.CredentialHelper, globalFile)...)
+		issues = append(issues, checkRequired(t, teamFile, globalFile)...)
+	}
+	if len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues, Warnings: warnings}
+	}
 
 	return &AppConfig{
-		Global: g,
-		Team:   t,
+		Global:   g,
+		Team:     t,
+		Warnings: warnings,
 	}, nil
 }
 
-func loadYAML(path string, v any) error {
-	data, err := os.ReadFile(path)
+// LoadGlobal читает только global.yaml — для команд, которым не нужна конкретная команда.
+func LoadGlobal(globalPath string) (*GlobalConfig, error) {
+	var g GlobalConfig
+	globalFile, issues, err := decodeStrict(globalPath, &g)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("load global: %w", err)
+	}
+	if globalFile != nil {
+		issues = append(issues, readSecretFiles(globalFile, globalSecrets(&g))...)
+		issues = append(issues, checkPortfolio(g.Portfolio, globalFile)...)
+	}
+	if len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
 	}
-	return yaml.Unmarshal(data, v)
+	return &g, nil
 }
 
+// merge дополняет конфиг команды значениями из global.yaml. К этому моменту *File-поля
+// уже прочитаны в соответствующие токены. Порядок поиска токенов:
+//
+//	Azure DevOps: azure.token (tokenFile) команды → azure.token (tokenFile) global.yaml →
+//	              auth.azurePat (azurePatFile) → auth.credentialHelper
+//	TeamCity:     teamcity.token (tokenFile) команды → auth.teamcityToken (teamcityTokenFile) →
+//	              auth.credentialHelper
+//
+// credential helper вызывается после merge и только для токенов, которых не нашлось.
 func merge(global GlobalConfig, team TeamConfig) *TeamConfig {
 	if team.AzureDevOps.Organisation == "" {
 		team.AzureDevOps.Organisation = global.AzureDevOps.Organization
@@ -44,5 +83,20 @@ func merge(global GlobalConfig, team TeamConfig) *TeamConfig {
 	if team.AzureDevOps.Token == "" {
 		team.AzureDevOps.Token = global.AzureDevOps.Token
 	}
+	if team.AzureDevOps.Token == "" {
+		team.AzureDevOps.Token = global.Auth.AzurePat
+	}
+	if team.TeamCity.BaseURL == "" {
+		team.TeamCity.BaseURL = global.TeamCity.BaseURL
+	}
+	if team.TeamCity.Token == "" {
+		team.TeamCity.Token = global.Auth.TeamCityToken
+	}
+	if team.Metrics.MaxBuilds == 0 {
+		team.Metrics.MaxBuilds = global.Defaults.MaxBuilds
+	}
+	if team.Metrics.DefaultBranch == "" {
+		team.Metrics.DefaultBranch = global.Defaults.Branch
+	}
 	return &team
 }
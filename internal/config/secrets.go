@@ -0,0 +1,216 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// credentialHelperTimeout ограничивает время ответа credential helper.
+const credentialHelperTimeout = 30 * time.Second
+
+// envPattern — ссылка на переменную окружения: ${NAME} или ${NAME:-значение по умолчанию}.
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// secretPaths — поля с токенами. Незаданная переменная в них означает пустое значение:
+// токен тогда ищется дальше — в *File-полях, global.yaml и у credential helper.
+var secretPaths = map[string]bool{
+	"azure.token":        true,
+	"auth.azurePat":      true,
+	"auth.teamcityToken": true,
+	"teamcity.token":     true,
+}
+
+// interpolateEnv подставляет переменные окружения во все скалярные значения YAML.
+// Незаданная переменная без значения по умолчанию вне полей с токенами — ошибка с позицией в файле.
+func interpolateEnv(file string, node *yaml.Node, path string) []Issue {
+	issues := make([]Issue, 0)
+
+	if node.Kind == yaml.ScalarNode && strings.Contains(node.Value, "${") {
+		value := envPattern.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			m := envPattern.FindStringSubmatch(ref)
+			if v, ok := os.LookupEnv(m[1]); ok {
+				return v
+			}
+			if m[2] != "" {
+				return m[3]
+			}
+			if secretPaths[path] {
+				return ""
+			}
+			issues = append(issues, Issue{File: file, Line: node.Line, Column: node.Column, Path: path,
+				Message: fmt.Sprintf("переменная окружения %s не задана", m[1])})
+			return ""
+		})
+
+		if value != node.Value {
+			node.Value = value
+			// тип значения без кавычек определяется заново: "${MAX_BUILDS}" может стать числом
+			if node.Style == 0 {
+				node.Tag = ""
+			}
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			issues = append(issues, interpolateEnv(file, node.Content[i+1], joinPath(path, node.Content[i].Value))...)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			issues = append(issues, interpolateEnv(file, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	default:
+		for _, child := range node.Content {
+			issues = append(issues, interpolateEnv(file, child, path)...)
+		}
+	}
+	return issues
+}
+
+// secretField — секрет, который можно задать значением или путём к файлу.
+type secretField struct {
+	value     *string
+	file      string
+	valuePath string
+	filePath  string
+}
+
+func globalSecrets(g *GlobalConfig) []secretField {
+	return []secretField{
+		{&g.AzureDevOps.Token, g.AzureDevOps.TokenFile, "azure.token", "azure.tokenFile"},
+		{&g.Auth.AzurePat, g.Auth.AzurePatFile, "auth.azurePat", "auth.azurePatFile"},
+		{&g.Auth.TeamCityToken, g.Auth.TeamCityTokenFile, "auth.teamcityToken", "auth.teamcityTokenFile"},
+	}
+}
+
+func teamSecrets(t *TeamConfig) []secretField {
+	return []secretField{
+		{&t.AzureDevOps.Token, t.AzureDevOps.TokenFile, "azure.token", "azure.tokenFile"},
+		{&t.TeamCity.Token, t.TeamCity.TokenFile, "teamcity.token", "teamcity.tokenFile"},
+	}
+}
+
+// readSecretFiles читает секреты из *File-полей. Относительный путь считается
+// от папки файла конфигурации, ~/ — от домашней директории.
+func readSecretFiles(f *yamlFile, secrets []secretField) []Issue {
+	issues := make([]Issue, 0)
+
+	for _, s := range secrets {
+		if s.file == "" {
+			continue
+		}
+		if *s.value != "" {
+			issues = append(issues, issueAt(s.filePath,
+				fmt.Sprintf("задано и %s, и %s — оставь что-то одно", s.valuePath, s.filePath),
+				fieldRef{file: f, path: s.filePath}))
+			continue
+		}
+
+		value, err := readSecretFile(s.file, filepath.Dir(f.path))
+		if err != nil {
+			issues = append(issues, issueAt(s.filePath, err.Error(), fieldRef{file: f, path: s.filePath}))
+			continue
+		}
+		*s.value = value
+	}
+
+	return issues
+}
+
+func readSecretFile(path, baseDir string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("не удалось получить домашнюю директорию: %w", err)
+		}
+		path = filepath.Join(home, rest)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("не удалось прочитать секрет: %w", err)
+	}
+
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("файл с секретом %s пуст", path)
+	}
+	return value, nil
+}
+
+// fillFromCredentialHelper запрашивает у credential helper токены, которых нет в конфигах.
+// TeamCity спрашивается, только если у команды есть конфигурации сборок.
+func fillFromCredentialHelper(t *TeamConfig, helper string, globalFile *yamlFile) []Issue {
+	if strings.TrimSpace(helper) == "" {
+		return nil
+	}
+
+	issues := make([]Issue, 0)
+	ask := func(token *string, rawURL string) {
+		if *token != "" || rawURL == "" {
+			return
+		}
+		secret, err := askCredentialHelper(helper, rawURL)
+		if err != nil {
+			issues = append(issues, issueAt("auth.credentialHelper", err.Error(),
+				fieldRef{file: globalFile, path: "auth.credentialHelper"}))
+			return
+		}
+		*token = secret
+	}
+
+	if org := t.AzureDevOps.Organisation; org != "" {
+		ask(&t.AzureDevOps.Token, "https://dev.azure.com/"+org)
+	}
+	if len(t.TeamCity.BuildConfigs) > 0 {
+		ask(&t.TeamCity.Token, t.TeamCity.BaseURL)
+	}
+
+	return issues
+}
+
+// askCredentialHelper вызывает «<helper> get» по протоколу git credential:
+// на stdin — protocol, host и path, из stdout берётся password.
+func askCredentialHelper(helper, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("некорректный адрес %s: %w", rawURL, err)
+	}
+
+	args := strings.Fields(helper)
+	ctx, cancel := context.WithTimeout(context.Background(), credentialHelperTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], append(args[1:], "get")...)
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=%s\nhost=%s\npath=%s\n\n",
+		u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/")))
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("credential helper %q не ответил для %s: %w", args[0], u.Host, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if password, ok := strings.CutPrefix(scanner.Text(), "password="); ok && password != "" {
+			return password, nil
+		}
+	}
+	return "", fmt.Errorf("credential helper %q не вернул password для %s", args[0], u.Host)
+}
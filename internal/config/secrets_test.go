@@ -0,0 +1,279 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestInterpolateEnv(t *testing.T) {
+	t.Setenv("SCRUM_EYE_TEST_PROJECT", "Platform")
+	t.Setenv("SCRUM_EYE_TEST_BUILDS", "40")
+	t.Setenv("SCRUM_EYE_TEST_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		team    string
+		project string
+		token   string
+		builds  int
+		issues  []Issue
+	}{
+		{
+			name:    "set variable",
+			team:    "azure:\n  project: ${SCRUM_EYE_TEST_PROJECT}\n",
+			project: "Platform",
+		},
+		{
+			name:    "variable inside a string",
+			team:    "azure:\n  project: \"${SCRUM_EYE_TEST_PROJECT}-${SCRUM_EYE_TEST_PROJECT}\"\n",
+			project: "Platform-Platform",
+		},
+		{
+			name:    "default for an unset variable",
+			team:    "azure:\n  project: ${SCRUM_EYE_TEST_UNSET:-Fallback}\n",
+			project: "Fallback",
+		},
+		{
+			name:    "default is ignored when the variable is set",
+			team:    "azure:\n  project: ${SCRUM_EYE_TEST_PROJECT:-Fallback}\n",
+			project: "Platform",
+		},
+		{
+			name: "set but empty variable wins over the default",
+			team: "azure:\n  project: ${SCRUM_EYE_TEST_EMPTY:-Fallback}\n",
+		},
+		{
+			name:   "unquoted number stays a number",
+			team:   "metrics:\n  maxBuilds: ${SCRUM_EYE_TEST_BUILDS}\n",
+			builds: 40,
+		},
+		{
+			name: "unset variable in a regular field",
+			team: "azure:\n  project: ${SCRUM_EYE_TEST_UNSET}\n",
+			issues: []Issue{{Line: 2, Column: 12, Path: "azure.project",
+				Message: "переменная окружения SCRUM_EYE_TEST_UNSET не задана"}},
+		},
+		{
+			name: "unset variable in a secret field is empty",
+			team: "azure:\n  token: ${SCRUM_EYE_TEST_UNSET}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "team.yaml", tt.team)
+
+			var team TeamConfig
+			_, issues, err := decodeStrict(path, &team)
+			if err != nil {
+				t.Fatalf("decodeStrict: %v", err)
+			}
+
+			for i := range tt.issues {
+				tt.issues[i].File = path
+			}
+			if len(issues) != 0 || len(tt.issues) != 0 {
+				if !reflect.DeepEqual(issues, tt.issues) {
+					t.Errorf("issues = %+v, want %+v", issues, tt.issues)
+				}
+			}
+			if team.AzureDevOps.ProjectId != tt.project {
+				t.Errorf("project = %q, want %q", team.AzureDevOps.ProjectId, tt.project)
+			}
+			if team.AzureDevOps.Token != tt.token {
+				t.Errorf("token = %q, want %q", team.AzureDevOps.Token, tt.token)
+			}
+			if team.Metrics.MaxBuilds != tt.builds {
+				t.Errorf("maxBuilds = %d, want %d", team.Metrics.MaxBuilds, tt.builds)
+			}
+		})
+	}
+}
+
+func TestSecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "secrets/azure.txt", "from-global-file\n")
+	writeFile(t, dir, "teams/secrets/tc.txt", "  from-team-file  \n")
+	writeFile(t, dir, "empty.txt", "\n")
+	globalPath := writeFile(t, dir, "global.yaml", `
+azure:
+  organization: my-org
+  tokenFile: secrets/azure.txt
+teamcity:
+  baseUrl: https://ci.example.com
+`)
+
+	load := func(team string) (*AppConfig, error) {
+		writeFile(t, dir, "teams/core.yaml", validTeam+team)
+		return Load(globalPath, filepath.Join(dir, "teams"), "core")
+	}
+
+	cfg, err := load("teamcity:\n  tokenFile: secrets/tc.txt\n  buildConfigs:\n    - id: Web\n")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	// относительные пути считаются от папки того файла, где они записаны
+	if cfg.Team.AzureDevOps.Token != "from-global-file" {
+		t.Errorf("azure token = %q, want it read next to global.yaml", cfg.Team.AzureDevOps.Token)
+	}
+	if cfg.Team.TeamCity.Token != "from-team-file" {
+		t.Errorf("teamcity token = %q, want it read next to the team config", cfg.Team.TeamCity.Token)
+	}
+
+	tests := []struct {
+		name    string
+		team    string
+		message string
+	}{
+		{"value and file together", "  token: inline\n  tokenFile: ../empty.txt\n", "задано и azure.token, и azure.tokenFile — оставь что-то одно"},
+		{"empty file", "  tokenFile: ../empty.txt\n", "пуст"},
+		{"missing file", "  tokenFile: missing.txt\n", "не удалось прочитать секрет"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(tt.team)
+			if err == nil || !strings.Contains(err.Error(), "azure.tokenFile: ") || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Load = %v, want an azure.tokenFile issue containing %q", err, tt.message)
+			}
+		})
+	}
+}
+
+// stubHelper записывает credential helper, который отвечает password=<prefix>-<host>
+// и дописывает host каждого запроса в calls.
+func stubHelper(t *testing.T, prefix string) (helper, calls string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credential helper stub is a shell script")
+	}
+
+	dir := t.TempDir()
+	calls = filepath.Join(dir, "calls")
+	script := `#!/bin/sh
+[ "$1" = get ] || exit 2
+while IFS='=' read -r key value; do
+  [ -z "$key" ] && break
+  [ "$key" = host ] && host=$value
+done
+echo "$host" >> "` + calls + `"
+echo "username=scrum-eye"
+echo "password=` + prefix + `-$host"
+`
+	helper = filepath.Join(dir, "helper")
+	if err := os.WriteFile(helper, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return helper, calls
+}
+
+func helperCalls(t *testing.T, calls string) []string {
+	t.Helper()
+	data, err := os.ReadFile(calls)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Fields(string(data))
+}
+
+func TestCredentialHelperPrecedence(t *testing.T) {
+	helper, _ := stubHelper(t, "helper")
+	auth := "auth:\n  credentialHelper: " + helper + "\n"
+	teamcity := "teamcity:\n  baseUrl: https://ci.example.com\n"
+
+	tests := []struct {
+		name     string
+		global   string
+		team     string
+		azure    string
+		teamCity string
+	}{
+		{
+			name:     "helper fills missing tokens",
+			global:   "azure:\n  organization: my-org\n" + auth + teamcity,
+			team:     validTeam + "teamcity:\n  buildConfigs:\n    - id: Web\n",
+			azure:    "helper-dev.azure.com",
+			teamCity: "helper-ci.example.com",
+		},
+		{
+			name:   "teamcity is not asked without build configs",
+			global: "azure:\n  organization: my-org\n" + auth + teamcity,
+			team:   validTeam,
+			azure:  "helper-dev.azure.com",
+		},
+		{
+			name:   "auth.azurePat wins over the helper",
+			global: "azure:\n  organization: my-org\n" + auth + "  azurePat: pat\n",
+			team:   validTeam,
+			azure:  "pat",
+		},
+		{
+			name:   "global azure.token wins over auth.azurePat",
+			global: "azure:\n  organization: my-org\n  token: global\n" + auth + "  azurePat: pat\n",
+			team:   validTeam,
+			azure:  "global",
+		},
+		{
+			name:     "team tokens win over everything",
+			global:   "azure:\n  organization: my-org\n  token: global\n" + auth + "  teamcityToken: tc-global\n" + teamcity,
+			team:     validTeam + "  token: team\nteamcity:\n  token: tc-team\n  buildConfigs:\n    - id: Web\n",
+			azure:    "team",
+			teamCity: "tc-team",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, verr, _, _ := load(t, tt.global, tt.team)
+			if verr != nil {
+				t.Fatalf("Load: %v", verr)
+			}
+			if got := cfg.Team.AzureDevOps.Token; got != tt.azure {
+				t.Errorf("azure token = %q, want %q", got, tt.azure)
+			}
+			if got := cfg.Team.TeamCity.Token; got != tt.teamCity {
+				t.Errorf("teamcity token = %q, want %q", got, tt.teamCity)
+			}
+		})
+	}
+}
+
+func TestCredentialHelperNotCalledWhenTokensAreSet(t *testing.T) {
+	helper, calls := stubHelper(t, "helper")
+
+	_, verr, _, _ := load(t, "azure:\n  organization: my-org\n  token: secret\nauth:\n  credentialHelper: "+helper+"\n", validTeam)
+	if verr != nil {
+		t.Fatalf("Load: %v", verr)
+	}
+	if got := helperCalls(t, calls); len(got) != 0 {
+		t.Errorf("helper called for %q, want no calls", got)
+	}
+}
+
+func TestCredentialHelperFailure(t *testing.T) {
+	helper, _ := stubHelper(t, "helper")
+
+	// первым аргументом заглушка получает --broken вместо get и завершается с ошибкой
+	global := "azure:\n  organization: my-org\nauth:\n  credentialHelper: sh " + helper + " --broken\n"
+	_, verr, globalPath, _ := load(t, global, validTeam)
+	if verr == nil {
+		t.Fatal("Load succeeded, want a credential helper issue")
+	}
+
+	if len(verr.Issues) != 2 {
+		t.Fatalf("issues = %+v, want the helper failure and the missing token", verr.Issues)
+	}
+	issue := verr.Issues[0]
+	if issue.File != globalPath || issue.Line != 4 || issue.Path != "auth.credentialHelper" || !strings.Contains(issue.Message, "не ответил для dev.azure.com") {
+		t.Errorf("issue = %+v, want a failure at auth.credentialHelper in global.yaml", issue)
+	}
+	if verr.Issues[1].Path != "azure.token" {
+		t.Errorf("issue = %+v, want the missing azure.token", verr.Issues[1])
+	}
+}
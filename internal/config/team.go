@@ -3,11 +3,86 @@ package config
 type AzureDevOpsTeam struct {
 	Organisation string `yaml:"organization"`
 	Token        string `yaml:"token"`
+	TokenFile    string `yaml:"tokenFile"`
 	ProjectId    string `yaml:"project"`
 	TeamId       string `yaml:"team"`
-	AreaPath     string `yaml:"area"`
+	// AreaPath — область (Area Path) команды. Выборку не ограничивает: элементы
+	// итерации берутся из настроек команды в Azure DevOps.
+	AreaPath     string    `yaml:"areaPath"`
+	MaxWorkItems int       `yaml:"maxWorkItems"`
+	Repos        []RepoRef `yaml:"repos"`
+
+	// Area и Board — ключи из старых шаблонов конфига. Читаются с предупреждением,
+	// значение области переносится в AreaPath.
+	Area  string      `yaml:"area"`
+	Board LegacyBoard `yaml:"board"`
+}
+
+// LegacyBoard — устаревшая секция azure.board.
+type LegacyBoard struct {
+	IterationPath string `yaml:"iterationPath"`
+	AreaPath      string `yaml:"areaPath"`
+}
+
+// RepoRef — репозиторий Azure Repos команды.
+type RepoRef struct {
+	Name          string `yaml:"name"`
+	DefaultBranch string `yaml:"defaultBranch"`
+}
+
+type BuildConfigRef struct {
+	ID     string `yaml:"id"`
+	Name   string `yaml:"name"`
+	Branch string `yaml:"branch"`
+}
+
+type TeamCityTeam struct {
+	BaseURL      string           `yaml:"baseUrl"`
+	Token        string           `yaml:"token"`
+	TokenFile    string           `yaml:"tokenFile"`
+	BuildConfigs []BuildConfigRef `yaml:"buildConfigs"`
+}
+
+type MetricsConfig struct {
+	MaxBuilds     int    `yaml:"maxBuilds"`
+	DefaultBranch string `yaml:"defaultBranch"`
+	WipLimit      int    `yaml:"wipLimit"`
+	WipPerPerson  int    `yaml:"wipPerPerson"`
+	// WipLevel — какие элементы считать в WIP: пусто — каждую работу один раз
+	// (задача не добавляется к своей истории в работе), WipLevelBacklog или WipLevelTasks.
+	WipLevel            string  `yaml:"wipLevel"`
+	OverloadStoryPoints float64 `yaml:"overloadStoryPoints"`
+	VelocitySprints     int     `yaml:"velocitySprints"`
+	// FlowSprints — сколько последних прошлых спринтов учитывать в cycle time и lead time.
+	FlowSprints    int `yaml:"flowSprints"`
+	ForecastTrials int `yaml:"forecastTrials"`
+}
+
+// Значения metrics.wipLevel.
+const (
+	// WipLevelBacklog — в WIP только элементы бэклога: истории и баги.
+	WipLevelBacklog = "backlog"
+	// WipLevelTasks — в WIP только задачи.
+	WipLevelTasks = "tasks"
+)
+
+// MappingConfig задаёт соответствие названий процесса доменным типам и категориям состояний.
+type MappingConfig struct {
+	Process string            `yaml:"process"`
+	Types   map[string]string `yaml:"types"`
+	States  map[string]string `yaml:"states"`
+}
+
+type DiffConfig struct {
+	BaselineDays int `yaml:"baselineDays"`
 }
 
 type TeamConfig struct {
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
 	AzureDevOps AzureDevOpsTeam `yaml:"azure"`
+	TeamCity    TeamCityTeam    `yaml:"teamcity"`
+	Metrics     MetricsConfig   `yaml:"metrics"`
+	Mapping     MappingConfig   `yaml:"mapping"`
+	Diff        DiffConfig      `yaml:"diff"`
 }
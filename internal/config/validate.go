@@ -0,0 +1,396 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// placeholderPrefix — так начинаются значения-заглушки в шаблонах конфигов.
+const placeholderPrefix = "CHANGE_ME"
+
+// Issue — одна проблема в конфиге с местом, где она найдена.
+// Line и Column нулевые, если место определить нельзя (например, поле отсутствует).
+type Issue struct {
+	File    string
+	Line    int
+	Column  int
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	var sb strings.Builder
+	sb.WriteString(i.File)
+	if i.Line > 0 {
+		fmt.Fprintf(&sb, ":%d:%d", i.Line, i.Column)
+	}
+	sb.WriteString(": ")
+	if i.Path != "" {
+		sb.WriteString(i.Path + ": ")
+	}
+	sb.WriteString(i.Message)
+	return sb.String()
+}
+
+// ValidationError — все найденные проблемы конфигурации.
+// Warnings — замечания, которые сами по себе загрузку не останавливают.
+type ValidationError struct {
+	Issues   []Issue
+	Warnings []Issue
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Issues))
+	for _, i := range e.Issues {
+		lines = append(lines, i.String())
+	}
+	return "ошибки в конфигурации:\n  " + strings.Join(lines, "\n  ")
+}
+
+// yamlFile — разобранный YAML-файл: дерево узлов нужно, чтобы указывать строку и колонку.
+type yamlFile struct {
+	path string
+	root *yaml.Node
+}
+
+// decodeStrict читает YAML в v, подставляя переменные окружения ${NAME}. Неизвестные поля,
+// незаданные переменные, ошибки синтаксиса и типов возвращаются как Issue с позицией в файле;
+// error — только для ошибок чтения.
+func decodeStrict(path string, v any) (*yamlFile, []Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f := &yamlFile{path: path, root: &yaml.Node{}}
+	if err := yaml.Unmarshal(data, f.root); err != nil {
+		return nil, []Issue{yamlErrorIssue(path, err)}, nil
+	}
+	if f.root.Kind == 0 {
+		// пустой файл
+		return f, nil, nil
+	}
+
+	issues := interpolateEnv(path, f.root, "")
+	issues = append(issues, unknownFields(path, f.root, reflect.TypeOf(v), "")...)
+
+	if err := f.root.Decode(v); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, nil, err
+		}
+		for _, msg := range typeErr.Errors {
+			issues = append(issues, yamlMessageIssue(path, msg))
+		}
+	}
+
+	return f, issues, nil
+}
+
+// unknownFields обходит узлы YAML параллельно с типом t и собирает ключи,
+// которым не соответствует ни одно поле структуры.
+func unknownFields(file string, node *yaml.Node, t reflect.Type, path string) []Issue {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return unknownFields(file, node.Content[0], t, path)
+	}
+
+	issues := make([]Issue, 0)
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			fieldPath := joinPath(path, key.Value)
+
+			ft, ok := fields[key.Value]
+			if !ok {
+				msg := "неизвестное поле"
+				if s := suggestField(key.Value, fields); s != "" {
+					msg += fmt.Sprintf(", возможно, имелось в виду %q", s)
+				}
+				issues = append(issues, Issue{File: file, Line: key.Line, Column: key.Column, Path: fieldPath, Message: msg})
+				continue
+			}
+			issues = append(issues, unknownFields(file, value, ft, fieldPath)...)
+		}
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			issues = append(issues, unknownFields(file, item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			issues = append(issues, unknownFields(file, node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))...)
+		}
+	}
+	return issues
+}
+
+// yamlFields возвращает поля структуры по их YAML-именам.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// suggestField ищет известное поле, похожее на опечатку key.
+func suggestField(key string, fields map[string]reflect.Type) string {
+	best, bestDist := "", 3
+	for name := range fields {
+		if strings.EqualFold(name, key) {
+			return name
+		}
+		lk, ln := strings.ToLower(key), strings.ToLower(name)
+		if strings.HasPrefix(ln, lk) || strings.HasPrefix(lk, ln) {
+			if best == "" || bestDist > 1 || name < best {
+				best, bestDist = name, 1
+			}
+			continue
+		}
+		if d := levenshtein(lk, ln); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// yamlErrorIssue переводит синтаксическую ошибку yaml ("yaml: line 3: ...") в Issue.
+func yamlErrorIssue(file string, err error) Issue {
+	return yamlMessageIssue(file, strings.TrimPrefix(err.Error(), "yaml: "))
+}
+
+func yamlMessageIssue(file, msg string) Issue {
+	issue := Issue{File: file, Message: msg}
+	var line int
+	if n, _ := fmt.Sscanf(msg, "line %d:", &line); n == 1 {
+		issue.Line = line
+		issue.Column = 1
+		_, issue.Message, _ = strings.Cut(msg, ": ")
+	}
+	return issue
+}
+
+// lookup находит узел-ключ по пути вида "azure.project".
+func (f *yamlFile) lookup(path string) *yaml.Node {
+	if f == nil || f.root == nil || len(f.root.Content) == 0 {
+		return nil
+	}
+
+	node := f.root.Content[0]
+	var key *yaml.Node
+	for _, part := range strings.Split(path, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		found := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == part {
+				key, node = node.Content[i], node.Content[i+1]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	return key
+}
+
+// fieldRef — поле в конкретном файле.
+type fieldRef struct {
+	file *yamlFile
+	path string
+}
+
+// issueAt создаёт Issue для поля path. Позиция берётся из первой ссылки, поле которой
+// задано в файле; если поле не задано нигде, указывается только первый файл.
+func issueAt(path, message string, refs ...fieldRef) Issue {
+	for _, r := range refs {
+		if key := r.file.lookup(r.path); key != nil {
+			return Issue{File: r.file.path, Line: key.Line, Column: key.Column, Path: r.path, Message: message}
+		}
+	}
+
+	issue := Issue{Path: path, Message: message}
+	if len(refs) > 0 && refs[0].file != nil {
+		issue.File = refs[0].file.path
+	}
+	return issue
+}
+
+// checkRequired проверяет итоговую конфигурацию команды после слияния с глобальной.
+func checkRequired(team TeamConfig, teamFile, globalFile *yamlFile) []Issue {
+	issues := make([]Issue, 0)
+
+	inTeam := func(path string) fieldRef { return fieldRef{file: teamFile, path: path} }
+	inGlobal := func(path string) fieldRef { return fieldRef{file: globalFile, path: path} }
+
+	required := func(value, what string, refs ...fieldRef) {
+		switch {
+		case strings.TrimSpace(value) == "":
+			issues = append(issues, issueAt(refs[0].path, "не задано: "+what, refs...))
+		case strings.HasPrefix(value, placeholderPrefix):
+			issues = append(issues, issueAt(refs[0].path, "осталось значение из шаблона: "+what, refs...))
+		}
+	}
+
+	az := team.AzureDevOps
+	required(az.Organisation, "организация Azure DevOps (в конфиге команды или global.yaml)",
+		inTeam("azure.organization"), inGlobal("azure.organization"))
+	if strings.Contains(az.Organisation, "/") {
+		issues = append(issues, issueAt("azure.organization",
+			"нужно имя организации, а не адрес: например, my-org вместо https://dev.azure.com/my-org",
+			inTeam("azure.organization"), inGlobal("azure.organization")))
+	}
+	required(az.Token, "PAT для Azure DevOps (azure.token, azure.tokenFile, auth.azurePat, auth.azurePatFile или auth.credentialHelper в global.yaml)",
+		inTeam("azure.token"), inGlobal("azure.token"), inGlobal("auth.azurePat"))
+	required(az.ProjectId, "проект Azure DevOps", inTeam("azure.project"))
+	required(az.TeamId, "команда Azure DevOps", inTeam("azure.team"))
+
+	if len(team.TeamCity.BuildConfigs) > 0 {
+		required(team.TeamCity.BaseURL, "адрес TeamCity (в конфиге команды или global.yaml)",
+			inTeam("teamcity.baseUrl"), inGlobal("teamcity.baseUrl"))
+		required(team.TeamCity.Token, "токен TeamCity (teamcity.token, teamcity.tokenFile, auth.teamcityToken, auth.teamcityTokenFile или auth.credentialHelper в global.yaml)",
+			inTeam("teamcity.token"), inGlobal("auth.teamcityToken"))
+	}
+	for i, bc := range team.TeamCity.BuildConfigs {
+		if strings.TrimSpace(bc.ID) == "" {
+			issues = append(issues, issueAt("teamcity.buildConfigs",
+				fmt.Sprintf("у конфигурации сборки №%d не задан id", i+1), inTeam("teamcity.buildConfigs")))
+		}
+	}
+
+	switch team.Metrics.WipLevel {
+	case "", WipLevelBacklog, WipLevelTasks:
+	default:
+		issues = append(issues, issueAt("metrics.wipLevel",
+			fmt.Sprintf("неизвестный уровень %q: пусто, %s или %s", team.Metrics.WipLevel, WipLevelBacklog, WipLevelTasks),
+			inTeam("metrics.wipLevel")))
+	}
+
+	for _, n := range []struct {
+		path  string
+		value float64
+	}{
+		{"azure.maxWorkItems", float64(az.MaxWorkItems)},
+		{"metrics.maxBuilds", float64(team.Metrics.MaxBuilds)},
+		{"metrics.wipLimit", float64(team.Metrics.WipLimit)},
+		{"metrics.wipPerPerson", float64(team.Metrics.WipPerPerson)},
+		{"metrics.overloadStoryPoints", team.Metrics.OverloadStoryPoints},
+		{"metrics.velocitySprints", float64(team.Metrics.VelocitySprints)},
+		{"metrics.flowSprints", float64(team.Metrics.FlowSprints)},
+		{"metrics.forecastTrials", float64(team.Metrics.ForecastTrials)},
+		{"diff.baselineDays", float64(team.Diff.BaselineDays)},
+	} {
+		if n.value < 0 {
+			issues = append(issues, issueAt(n.path, "значение не может быть отрицательным", inTeam(n.path)))
+		}
+	}
+
+	return issues
+}
+
+// checkPortfolio проверяет секцию portfolio в global.yaml.
+func checkPortfolio(p PortfolioConfig, globalFile *yamlFile) []Issue {
+	issues := make([]Issue, 0)
+
+	if p.Concurrency < 0 {
+		issues = append(issues, issueAt("portfolio.concurrency", "значение не может быть отрицательным",
+			fieldRef{file: globalFile, path: "portfolio.concurrency"}))
+	}
+	for name, teams := range p.Groups {
+		path := "portfolio.groups." + name
+		if len(teams) == 0 {
+			issues = append(issues, issueAt(path, "в группе нет ни одной команды", fieldRef{file: globalFile, path: path}))
+		}
+		for _, team := range teams {
+			if strings.TrimSpace(team) == "" || strings.ContainsAny(team, `/\`) {
+				issues = append(issues, issueAt(path, fmt.Sprintf("некорректное имя команды %q", team),
+					fieldRef{file: globalFile, path: path}))
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues
+}
+
+// deprecatedTeamFields — устаревшие ключи конфига команды и что использовать вместо них.
+var deprecatedTeamFields = []struct {
+	path string
+	hint string
+}{
+	{"azure.area", "используй azure.areaPath"},
+	{"azure.board", "используй azure.areaPath; iterationPath больше не нужен, спринт выбирается через --sprint"},
+}
+
+// migrateDeprecated переносит значения устаревших полей в новые и возвращает предупреждения
+// для каждого найденного устаревшего ключа.
+func migrateDeprecated(t *TeamConfig, teamFile *yamlFile) []Issue {
+	warnings := make([]Issue, 0)
+	for _, d := range deprecatedTeamFields {
+		if key := teamFile.lookup(d.path); key != nil {
+			warnings = append(warnings, Issue{File: teamFile.path, Line: key.Line, Column: key.Column,
+				Path: d.path, Message: "устаревшее поле, " + d.hint})
+		}
+	}
+
+	az := &t.AzureDevOps
+	if az.AreaPath == "" {
+		az.AreaPath = az.Area
+	}
+	if az.AreaPath == "" {
+		az.AreaPath = az.Board.AreaPath
+	}
+	return warnings
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
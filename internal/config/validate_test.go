@@ -0,0 +1,318 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// validTeam — минимальный конфиг команды, который проходит все проверки вместе с validGlobal.
+const validTeam = `
+azure:
+  project: Platform
+  team: Core
+`
+
+const validGlobal = `
+azure:
+  organization: my-org
+  token: secret
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.TrimPrefix(content, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// load записывает global.yaml и teams/core.yaml во временную папку и загружает их.
+func load(t *testing.T, global, team string) (*AppConfig, *ValidationError, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	globalPath := writeFile(t, dir, "global.yaml", global)
+	teamPath := writeFile(t, dir, "teams/core.yaml", team)
+
+	cfg, err := Load(globalPath, filepath.Join(dir, "teams"), "core")
+	var verr *ValidationError
+	if err != nil && !errors.As(err, &verr) {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg, verr, globalPath, teamPath
+}
+
+func TestDecodeStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []Issue
+	}{
+		{
+			name: "valid",
+			yaml: validTeam,
+		},
+		{
+			name: "empty file",
+			yaml: "",
+		},
+		{
+			name: "unknown field with a suggestion",
+			yaml: "azure:\n  project: Platform\n  organisaton: my-org\n",
+			want: []Issue{{Line: 3, Column: 3, Path: "azure.organisaton",
+				Message: `неизвестное поле, возможно, имелось в виду "organization"`}},
+		},
+		{
+			name: "unknown field differing only in case",
+			yaml: "metrics:\n  wiplimit: 3\n",
+			want: []Issue{{Line: 2, Column: 3, Path: "metrics.wiplimit",
+				Message: `неизвестное поле, возможно, имелось в виду "wipLimit"`}},
+		},
+		{
+			name: "unknown field without a suggestion",
+			yaml: "azure:\n  project: Platform\nsomethingElse: 1\n",
+			want: []Issue{{Line: 3, Column: 1, Path: "somethingElse", Message: "неизвестное поле"}},
+		},
+		{
+			name: "unknown field inside a list",
+			yaml: "teamcity:\n  buildConfigs:\n    - id: Web\n    - id: Api\n      brnch: main\n",
+			want: []Issue{{Line: 5, Column: 7, Path: "teamcity.buildConfigs[1].brnch",
+				Message: `неизвестное поле, возможно, имелось в виду "branch"`}},
+		},
+		{
+			name: "wrong type",
+			yaml: "metrics:\n  wipLimit: many\n",
+			want: []Issue{{Line: 2, Column: 1, Message: "cannot unmarshal !!str `many` into int"}},
+		},
+		{
+			name: "syntax error",
+			yaml: "azure:\n  project: Platform\n team: Core\n",
+			want: []Issue{{Line: 2, Column: 1, Message: "did not find expected key"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "team.yaml", tt.yaml)
+
+			var team TeamConfig
+			_, issues, err := decodeStrict(path, &team)
+			if err != nil {
+				t.Fatalf("decodeStrict: %v", err)
+			}
+
+			for i := range tt.want {
+				tt.want[i].File = path
+			}
+			if len(issues) != 0 || len(tt.want) != 0 {
+				if !reflect.DeepEqual(issues, tt.want) {
+					t.Errorf("issues = %+v, want %+v", issues, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeStrictMissingFile(t *testing.T) {
+	var team TeamConfig
+	if _, _, err := decodeStrict(filepath.Join(t.TempDir(), "missing.yaml"), &team); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestIssueString(t *testing.T) {
+	tests := []struct {
+		issue Issue
+		want  string
+	}{
+		{Issue{File: "core.yaml", Line: 3, Column: 5, Path: "azure.team", Message: "не задано"}, "core.yaml:3:5: azure.team: не задано"},
+		{Issue{File: "core.yaml", Path: "azure.team", Message: "не задано"}, "core.yaml: azure.team: не задано"},
+		{Issue{File: "core.yaml", Line: 1, Column: 1, Message: "did not find expected key"}, "core.yaml:1:1: did not find expected key"},
+	}
+	for _, tt := range tests {
+		if got := tt.issue.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestLoadRequiredFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		global string
+		team   string
+		// want — проблемы без File: он подставляется по inGlobal
+		want     []Issue
+		inGlobal []bool
+	}{
+		{
+			name:   "valid",
+			global: validGlobal,
+			team:   validTeam,
+		},
+		{
+			name:     "missing fields point to the team file",
+			global:   validGlobal,
+			team:     "azure:\n  team: Core\n",
+			want:     []Issue{{Path: "azure.project", Message: "не задано: проект Azure DevOps"}},
+			inGlobal: []bool{false},
+		},
+		{
+			name:     "placeholder is reported where it is written",
+			global:   validGlobal,
+			team:     "azure:\n  project: CHANGE_ME\n  team: Core\n",
+			want:     []Issue{{Line: 2, Column: 3, Path: "azure.project", Message: "осталось значение из шаблона: проект Azure DevOps"}},
+			inGlobal: []bool{false},
+		},
+		{
+			name:   "organization url found in global.yaml",
+			global: "azure:\n  organization: https://dev.azure.com/my-org\n  token: secret\n",
+			team:   validTeam,
+			want: []Issue{{Line: 2, Column: 3, Path: "azure.organization",
+				Message: "нужно имя организации, а не адрес: например, my-org вместо https://dev.azure.com/my-org"}},
+			inGlobal: []bool{true},
+		},
+		{
+			name:   "teamcity is required only with build configs",
+			global: validGlobal,
+			team:   validTeam + "teamcity:\n  buildConfigs:\n    - name: Web\n",
+			want: []Issue{
+				{Path: "teamcity.baseUrl", Message: "не задано: адрес TeamCity (в конфиге команды или global.yaml)"},
+				{Path: "teamcity.token", Message: "не задано: токен TeamCity (teamcity.token, teamcity.tokenFile, auth.teamcityToken, auth.teamcityTokenFile или auth.credentialHelper в global.yaml)"},
+				{Line: 5, Column: 3, Path: "teamcity.buildConfigs", Message: "у конфигурации сборки №1 не задан id"},
+			},
+			inGlobal: []bool{false, false, false},
+		},
+		{
+			name:     "unknown wip level",
+			global:   validGlobal,
+			team:     validTeam + "metrics:\n  wipLevel: stories\n",
+			want:     []Issue{{Line: 5, Column: 3, Path: "metrics.wipLevel", Message: `неизвестный уровень "stories": пусто, backlog или tasks`}},
+			inGlobal: []bool{false},
+		},
+		{
+			name:     "negative numbers",
+			global:   validGlobal,
+			team:     validTeam + "diff:\n  baselineDays: -1\n",
+			want:     []Issue{{Line: 5, Column: 3, Path: "diff.baselineDays", Message: "значение не может быть отрицательным"}},
+			inGlobal: []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, verr, globalPath, teamPath := load(t, tt.global, tt.team)
+
+			if len(tt.want) == 0 {
+				if verr != nil {
+					t.Fatalf("Load: %v", verr)
+				}
+				if cfg.Team.AzureDevOps.Organisation != "my-org" || cfg.Team.AzureDevOps.Token != "secret" {
+					t.Errorf("team azure = %+v, want organization and token from global.yaml", cfg.Team.AzureDevOps)
+				}
+				return
+			}
+			if verr == nil {
+				t.Fatalf("Load succeeded, want %d issues", len(tt.want))
+			}
+
+			for i := range tt.want {
+				tt.want[i].File = teamPath
+				if tt.inGlobal[i] {
+					tt.want[i].File = globalPath
+				}
+			}
+			if !reflect.DeepEqual(verr.Issues, tt.want) {
+				t.Errorf("issues =\n%+v\nwant\n%+v", verr.Issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCollectsIssuesFromBothFiles(t *testing.T) {
+	_, verr, globalPath, teamPath := load(t, "azure:\n  organizaton: my-org\n", "azure:\n  projct: Platform\n")
+	if verr == nil {
+		t.Fatal("Load succeeded, want issues")
+	}
+
+	var files []string
+	for _, issue := range verr.Issues {
+		if issue.Line > 0 {
+			files = append(files, issue.File)
+		}
+	}
+	if want := []string{globalPath, teamPath}; !reflect.DeepEqual(files, want) {
+		t.Errorf("positioned issues in %q, want unknown fields in %q", files, want)
+	}
+}
+
+func TestLoadMigratesDeprecatedFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		azure    string
+		areaPath string
+		warnings []Issue
+	}{
+		{
+			name:     "azure.area",
+			azure:    "  area: Platform\\Core\n",
+			areaPath: `Platform\Core`,
+			warnings: []Issue{{Line: 4, Column: 3, Path: "azure.area", Message: "устаревшее поле, используй azure.areaPath"}},
+		},
+		{
+			name:     "azure.board.areaPath",
+			azure:    "  board:\n    iterationPath: Platform\\Sprint 1\n    areaPath: Platform\\Board\n",
+			areaPath: `Platform\Board`,
+			warnings: []Issue{{Line: 4, Column: 3, Path: "azure.board",
+				Message: "устаревшее поле, используй azure.areaPath; iterationPath больше не нужен, спринт выбирается через --sprint"}},
+		},
+		{
+			name:     "areaPath wins over legacy fields",
+			azure:    "  areaPath: Platform\\New\n  area: Platform\\Old\n",
+			areaPath: `Platform\New`,
+			warnings: []Issue{{Line: 5, Column: 3, Path: "azure.area", Message: "устаревшее поле, используй azure.areaPath"}},
+		},
+		{
+			name:     "no legacy fields",
+			azure:    "  areaPath: Platform\\Core\n",
+			areaPath: `Platform\Core`,
+			warnings: []Issue{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, verr, _, teamPath := load(t, validGlobal, validTeam+tt.azure)
+			if verr != nil {
+				t.Fatalf("Load: %v, want deprecated fields to load", verr)
+			}
+
+			if got := cfg.Team.AzureDevOps.AreaPath; got != tt.areaPath {
+				t.Errorf("AreaPath = %q, want %q", got, tt.areaPath)
+			}
+			for i := range tt.warnings {
+				tt.warnings[i].File = teamPath
+			}
+			if !reflect.DeepEqual(cfg.Warnings, tt.warnings) {
+				t.Errorf("Warnings = %+v, want %+v", cfg.Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestLoadKeepsWarningsOnError(t *testing.T) {
+	_, verr, _, _ := load(t, validGlobal, "azure:\n  team: Core\n  area: Platform\n")
+	if verr == nil {
+		t.Fatal("Load succeeded, want a missing azure.project")
+	}
+	if len(verr.Warnings) != 1 || verr.Warnings[0].Path != "azure.area" {
+		t.Errorf("Warnings = %+v, want the azure.area warning alongside the error", verr.Warnings)
+	}
+}
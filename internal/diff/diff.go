@@ -1 +1,114 @@
 package diff
+
+import (
+	"sort"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// Change — изменение строкового поля рабочего элемента (состояние, исполнитель, заголовок).
+type Change struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// EstimateChange — изменение одной из оценок рабочего элемента.
+type EstimateChange struct {
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+	Field string  `json:"field"`
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+}
+
+// Result — разница между снимком-базой и текущим состоянием спринта.
+type Result struct {
+	BaselineAt time.Time `json:"baselineAt"`
+	// SprintChanged выставляется, если база снята для другого спринта.
+	SprintChanged   bool              `json:"sprintChanged"`
+	BaselineSprint  string            `json:"baselineSprint"`
+	Added           []domain.WorkItem `json:"added"`
+	Removed         []domain.WorkItem `json:"removed"`
+	StateChanges    []Change          `json:"stateChanges"`
+	Reassignments   []Change          `json:"reassignments"`
+	EstimateChanges []EstimateChange  `json:"estimateChanges"`
+	TitleChanges    []Change          `json:"titleChanges"`
+}
+
+// IsEmpty сообщает, что с момента базы ничего не изменилось.
+func (r *Result) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.StateChanges) == 0 &&
+		len(r.Reassignments) == 0 && len(r.EstimateChanges) == 0 && len(r.TitleChanges) == 0
+}
+
+// Compare сравнивает рабочие элементы текущего спринта со снимком baseline.
+func Compare(baseline, current *domain.Project, baselineAt time.Time) *Result {
+	res := &Result{BaselineAt: baselineAt}
+
+	var before, after *domain.Sprint
+	if baseline != nil {
+		before = baseline.CurrentSprint
+	}
+	if current != nil {
+		after = current.CurrentSprint
+	}
+	if before == nil {
+		before = &domain.Sprint{}
+	}
+	if after == nil {
+		after = &domain.Sprint{}
+	}
+
+	res.BaselineSprint = before.Name
+	res.SprintChanged = before.ID != after.ID
+
+	old := make(map[int]domain.WorkItem, len(before.WorkItems))
+	for _, wi := range before.WorkItems {
+		old[wi.ID] = wi
+	}
+
+	seen := make(map[int]bool, len(after.WorkItems))
+	for _, wi := range after.WorkItems {
+		seen[wi.ID] = true
+
+		prev, ok := old[wi.ID]
+		if !ok {
+			res.Added = append(res.Added, wi)
+			continue
+		}
+
+		if prev.State != wi.State {
+			res.StateChanges = append(res.StateChanges, Change{ID: wi.ID, Name: wi.Name, From: prev.State, To: wi.State})
+		}
+		if prev.AssignedTo != wi.AssignedTo {
+			res.Reassignments = append(res.Reassignments, Change{ID: wi.ID, Name: wi.Name, From: prev.AssignedTo, To: wi.AssignedTo})
+		}
+		if prev.Name != wi.Name {
+			res.TitleChanges = append(res.TitleChanges, Change{ID: wi.ID, Name: wi.Name, From: prev.Name, To: wi.Name})
+		}
+
+		res.EstimateChanges = appendEstimate(res.EstimateChanges, wi, "storyPoints", prev.StoryPoints, wi.StoryPoints)
+		res.EstimateChanges = appendEstimate(res.EstimateChanges, wi, "originalEstimate", prev.OriginalEstimate, wi.OriginalEstimate)
+		res.EstimateChanges = appendEstimate(res.EstimateChanges, wi, "remainingWork", prev.RemainingWork, wi.RemainingWork)
+	}
+
+	for _, wi := range before.WorkItems {
+		if !seen[wi.ID] {
+			res.Removed = append(res.Removed, wi)
+		}
+	}
+
+	sort.Slice(res.Removed, func(i, j int) bool { return res.Removed[i].ID < res.Removed[j].ID })
+
+	return res
+}
+
+func appendEstimate(dst []EstimateChange, wi domain.WorkItem, field string, from, to float64) []EstimateChange {
+	if from == to {
+		return dst
+	}
+	return append(dst, EstimateChange{ID: wi.ID, Name: wi.Name, Field: field, From: from, To: to})
+}
@@ -0,0 +1,140 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+var baselineAt = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+func sprint(id string, items ...domain.WorkItem) *domain.Project {
+	return &domain.Project{CurrentSprint: &domain.Sprint{ID: id, Name: "Sprint " + id, WorkItems: items}}
+}
+
+func story(id int, mutate func(*domain.WorkItem)) domain.WorkItem {
+	wi := domain.WorkItem{ID: id, Name: "story", State: "Active", AssignedTo: "Ann", StoryPoints: 3}
+	if mutate != nil {
+		mutate(&wi)
+	}
+	return wi
+}
+
+func ids(items []domain.WorkItem) []int {
+	var out []int
+	for _, wi := range items {
+		out = append(out, wi.ID)
+	}
+	return out
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseline *domain.Project
+		current  *domain.Project
+		want     Result
+	}{
+		{
+			name:     "no changes",
+			baseline: sprint("s1", story(1, nil)),
+			current:  sprint("s1", story(1, nil)),
+			want:     Result{BaselineSprint: "Sprint s1"},
+		},
+		{
+			name:     "added",
+			baseline: sprint("s1", story(1, nil)),
+			current:  sprint("s1", story(1, nil), story(2, nil)),
+			want:     Result{BaselineSprint: "Sprint s1", Added: []domain.WorkItem{story(2, nil)}},
+		},
+		{
+			name:     "removed in id order",
+			baseline: sprint("s1", story(3, nil), story(1, nil), story(2, nil)),
+			current:  sprint("s1", story(2, nil)),
+			want:     Result{BaselineSprint: "Sprint s1", Removed: []domain.WorkItem{story(1, nil), story(3, nil)}},
+		},
+		{
+			name:     "state",
+			baseline: sprint("s1", story(1, nil)),
+			current:  sprint("s1", story(1, func(wi *domain.WorkItem) { wi.State = "Closed" })),
+			want: Result{BaselineSprint: "Sprint s1", StateChanges: []Change{
+				{ID: 1, Name: "story", From: "Active", To: "Closed"},
+			}},
+		},
+		{
+			name:     "reassignment",
+			baseline: sprint("s1", story(1, nil)),
+			current:  sprint("s1", story(1, func(wi *domain.WorkItem) { wi.AssignedTo = "" })),
+			want: Result{BaselineSprint: "Sprint s1", Reassignments: []Change{
+				{ID: 1, Name: "story", From: "Ann", To: ""},
+			}},
+		},
+		{
+			name:     "estimates",
+			baseline: sprint("s1", story(1, func(wi *domain.WorkItem) { wi.RemainingWork = 8 })),
+			current: sprint("s1", story(1, func(wi *domain.WorkItem) {
+				wi.StoryPoints, wi.OriginalEstimate, wi.RemainingWork = 5, 16, 8
+			})),
+			want: Result{BaselineSprint: "Sprint s1", EstimateChanges: []EstimateChange{
+				{ID: 1, Name: "story", Field: "storyPoints", From: 3, To: 5},
+				{ID: 1, Name: "story", Field: "originalEstimate", From: 0, To: 16},
+			}},
+		},
+		{
+			name:     "title",
+			baseline: sprint("s1", story(1, nil)),
+			current:  sprint("s1", story(1, func(wi *domain.WorkItem) { wi.Name = "renamed" })),
+			want: Result{BaselineSprint: "Sprint s1", TitleChanges: []Change{
+				{ID: 1, Name: "renamed", From: "story", To: "renamed"},
+			}},
+		},
+		{
+			name:     "sprint changed",
+			baseline: sprint("s1", story(1, nil)),
+			current:  sprint("s2", story(2, nil)),
+			want: Result{
+				BaselineSprint: "Sprint s1",
+				SprintChanged:  true,
+				Added:          []domain.WorkItem{story(2, nil)},
+				Removed:        []domain.WorkItem{story(1, nil)},
+			},
+		},
+		{
+			name:     "nil baseline",
+			baseline: nil,
+			current:  sprint("s1", story(1, nil)),
+			want:     Result{SprintChanged: true, Added: []domain.WorkItem{story(1, nil)}},
+		},
+		{
+			name:     "baseline without a sprint",
+			baseline: &domain.Project{},
+			current:  nil,
+			want:     Result{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(tt.baseline, tt.current, baselineAt)
+
+			tt.want.BaselineAt = baselineAt
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Compare =\n%+v\nwant\n%+v", *got, tt.want)
+			}
+			if empty := len(tt.want.Added)+len(tt.want.Removed)+len(tt.want.StateChanges)+
+				len(tt.want.Reassignments)+len(tt.want.EstimateChanges)+len(tt.want.TitleChanges) == 0; got.IsEmpty() != empty {
+				t.Errorf("IsEmpty = %v, want %v", got.IsEmpty(), empty)
+			}
+		})
+	}
+}
+
+func TestCompareKeepsCurrentOrder(t *testing.T) {
+	got := Compare(sprint("s1"), sprint("s1", story(5, nil), story(2, nil), story(9, nil)), baselineAt)
+
+	if want := []int{5, 2, 9}; !reflect.DeepEqual(ids(got.Added), want) {
+		t.Errorf("Added = %v, want %v in the current sprint order", ids(got.Added), want)
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+type BuildStatus string
+
+const (
+	BuildSuccess BuildStatus = "Success"
+	BuildFailure BuildStatus = "Failure"
+	BuildUnknown BuildStatus = "Unknown"
+)
+
+type TestCounts struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Ignored int `json:"ignored"`
+	Muted   int `json:"muted"`
+}
+
+type Build struct {
+	ID         string      `json:"id"`
+	Number     string      `json:"number"`
+	Branch     string      `json:"branch,omitempty"`
+	Status     BuildStatus `json:"status"`
+	StatusText string      `json:"statusText,omitempty"`
+	WebURL     string      `json:"webUrl,omitempty"`
+	QueuedAt   *time.Time  `json:"queuedAt,omitempty"`
+	StartedAt  *time.Time  `json:"startedAt,omitempty"`
+	FinishedAt *time.Time  `json:"finishedAt,omitempty"`
+	// Duration и QueueTime сериализуются в наносекундах.
+	Duration    time.Duration `json:"duration"`
+	QueueTime   time.Duration `json:"queueTime"`
+	TriggeredBy string        `json:"triggeredBy,omitempty"`
+	Changes     int           `json:"changes"`
+	Tests       TestCounts    `json:"tests"`
+}
+
+// BuildConfiguration — конфигурация сборки и её последние сборки, от новых к старым.
+type BuildConfiguration struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Branch string  `json:"branch,omitempty"`
+	Builds []Build `json:"builds"`
+}
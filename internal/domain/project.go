@@ -1,5 +1,12 @@
 package domain
 
 type Project struct {
-	CurrentSprint *Sprint
+	CurrentSprint *Sprint `json:"currentSprint"`
+	// SprintHistory — завершённые спринты перед CurrentSprint, от старых к новым.
+	SprintHistory []Sprint             `json:"sprintHistory,omitempty"`
+	BuildConfigs  []BuildConfiguration `json:"buildConfigs,omitempty"`
+	// PullRequests — последние пулл-реквесты репозиториев из azure.repos.
+	PullRequests []PullRequest `json:"pullRequests,omitempty"`
+	// Warnings — предупреждения, возникшие при сборе данных (например, неполная выгрузка).
+	Warnings []string `json:"warnings,omitempty"`
 }
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// PullRequest — пулл-реквест в одном из репозиториев команды.
+type PullRequest struct {
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	Repository string `json:"repository"`
+	// SourceBranch и TargetBranch — имена веток без префикса refs/heads/.
+	SourceBranch string     `json:"sourceBranch"`
+	TargetBranch string     `json:"targetBranch"`
+	Status       string     `json:"status"`
+	CreatedBy    string     `json:"createdBy,omitempty"`
+	CreatedAt    *time.Time `json:"createdAt,omitempty"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+	IsDraft      bool       `json:"isDraft,omitempty"`
+}
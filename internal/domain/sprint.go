@@ -5,24 +5,90 @@ import "time"
 type WorkItemType string
 
 const (
-	WorkItemStory   WorkItemType = "Story"
-	WorkItemBug     WorkItemType = "Bug"
-	WorkItemTask    WorkItemType = "Task"
-	WorkItemEpic    WorkItemType = "Epic"
-	WorkItemFeature WorkItemType = "Feature"
-	WorkItemUnknown WorkItemType = "Unknown"
+	WorkItemStory      WorkItemType = "Story"
+	WorkItemBug        WorkItemType = "Bug"
+	WorkItemTask       WorkItemType = "Task"
+	WorkItemEpic       WorkItemType = "Epic"
+	WorkItemFeature    WorkItemType = "Feature"
+	WorkItemImpediment WorkItemType = "Impediment"
+	WorkItemUnknown    WorkItemType = "Unknown"
+)
+
+// StateCategory — категория состояния рабочего элемента, не зависящая от процесса.
+type StateCategory string
+
+const (
+	StateProposed   StateCategory = "Proposed"
+	StateInProgress StateCategory = "InProgress"
+	StateResolved   StateCategory = "Resolved"
+	StateCompleted  StateCategory = "Completed"
+	StateRemoved    StateCategory = "Removed"
+	StateUnknown    StateCategory = "Unknown"
 )
 
 type WorkItem struct {
-	ID   int
-	Name string
-	Type WorkItemType
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Type          WorkItemType  `json:"type"`
+	URL           string        `json:"url,omitempty"`
+	State         string        `json:"state,omitempty"`
+	StateCategory StateCategory `json:"stateCategory"`
+	AssignedTo    string        `json:"assignedTo,omitempty"`
+	// StoryPoints — оценка элемента: Story Points, Effort или Size в зависимости от процесса.
+	StoryPoints      float64    `json:"storyPoints,omitempty"`
+	OriginalEstimate float64    `json:"originalEstimate,omitempty"`
+	RemainingWork    float64    `json:"remainingWork,omitempty"`
+	CompletedWork    float64    `json:"completedWork,omitempty"`
+	Priority         int        `json:"priority,omitempty"`
+	Severity         string     `json:"severity,omitempty"`
+	ParentID         int        `json:"parentId,omitempty"`
+	Tags             []string   `json:"tags,omitempty"`
+	AreaPath         string     `json:"areaPath,omitempty"`
+	CreatedDate      *time.Time `json:"createdDate,omitempty"`
+	ActivatedDate    *time.Time `json:"activatedDate,omitempty"`
+	ResolvedDate     *time.Time `json:"resolvedDate,omitempty"`
+	ClosedDate       *time.Time `json:"closedDate,omitempty"`
+	ChangedDate      *time.Time `json:"changedDate,omitempty"`
+	StateChangeDate  *time.Time `json:"stateChangeDate,omitempty"`
+	// AddedToSprintAt и AddedToSprintBy — когда и кем элемент последний раз перенесён в свой спринт.
+	AddedToSprintAt *time.Time `json:"addedToSprintAt,omitempty"`
+	AddedToSprintBy string     `json:"addedToSprintBy,omitempty"`
+	// Transitions — история смены состояний, от старых к новым. Пусто, если история не загружалась.
+	Transitions []StateTransition `json:"transitions,omitempty"`
+}
+
+// StateTransition — переход рабочего элемента в новое состояние.
+type StateTransition struct {
+	At       time.Time     `json:"at"`
+	From     string        `json:"from,omitempty"`
+	To       string        `json:"to"`
+	Category StateCategory `json:"category"`
+	By       string        `json:"by,omitempty"`
 }
 
 type Sprint struct {
-	ID        string
-	Name      string
-	StartDate *time.Time
-	EndDate   *time.Time
-	WorkItems []WorkItem
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+	// TimeFrame — past, current или future относительно даты запуска.
+	TimeFrame string     `json:"timeFrame,omitempty"`
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	WorkItems []WorkItem `json:"workItems"`
+	// CommittedIDs — элементы, которые были в итерации к концу первого дня спринта,
+	// по истории ревизий. nil, если история для спринта не загружалась.
+	CommittedIDs []int `json:"committedIds,omitempty"`
+}
+
+// IsCurrent сообщает, что спринт идёт сейчас.
+func (s *Sprint) IsCurrent() bool {
+	return s.TimeFrame == "" || s.TimeFrame == "current"
+}
+
+// RecentSprints возвращает последние n спринтов истории (история идёт от старых к новым).
+func RecentSprints(history []Sprint, n int) []Sprint {
+	if n <= 0 {
+		return nil
+	}
+	return history[max(0, len(history)-n):]
 }
@@ -0,0 +1,30 @@
+package report
+
+import (
+	"fmt"
+
+	"scrum-eye/internal/analysis"
+)
+
+// PrintAgingWIP выводит элементы, застрявшие в текущем состоянии дольше 85-го процентиля cycle time.
+func PrintAgingWIP(a *analysis.AgingWIP) {
+	if a == nil {
+		return
+	}
+
+	boxTop(fmt.Sprintf(" 🐢 Aging WIP (older than %.1f days)", a.ThresholdDays))
+
+	if len(a.Items) == 0 {
+		boxLine("   Nothing is stuck 👍")
+		boxBottom()
+		return
+	}
+
+	boxLine(fmt.Sprintf("   %-6s %5s  %-10s %-14s %s", "ID", "Age", "State", "Assigned To", "Name"))
+	boxLine(fmt.Sprintf("   %-6s %5s  %-10s %-14s %s", "------", "-----", "----------", "--------------", "----------"))
+	for _, it := range a.Items {
+		boxLine(fmt.Sprintf("   %-6d %4.0fd  %-10s %-14s %s",
+			it.ID, it.AgeDays, truncate(it.State, 10), truncate(it.AssignedTo, 14), it.Name))
+	}
+	boxBottom()
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"scrum-eye/internal/analysis"
+)
+
+const burndownRows = 8
+
+// PrintBurndown выводит ASCII-график сгорания: столбцы — остаток, «·» — идеальная линия,
+// «─» — объём спринта. Если story points не проставлены, график строится по остатку часов.
+func PrintBurndown(bd *analysis.Burndown) {
+	if bd == nil || len(bd.Points) == 0 {
+		return
+	}
+
+	unit, remaining, ideal, scope := burndownSeries(bd)
+
+	maxV := 0.0
+	for i := range remaining {
+		maxV = max(maxV, remaining[i], ideal[i], scope[i])
+	}
+
+	boxTop(fmt.Sprintf(" 📉 Burndown (%s)", unit))
+
+	if maxV == 0 {
+		boxLine("   No data yet: snapshots are saved on every run")
+		boxBottom()
+		return
+	}
+
+	colWidth := 2
+	if len(bd.Points)*2 > boxWidth-10 {
+		colWidth = 1
+	}
+	cell := func(s string) string { return strings.Repeat(s, colWidth) }
+
+	for r := 0; r < burndownRows; r++ {
+		upper := maxV * float64(burndownRows-r) / burndownRows
+		lower := maxV * float64(burndownRows-r-1) / burndownRows
+		within := func(v float64) bool { return v > lower && v <= upper }
+
+		var sb strings.Builder
+		for i, p := range bd.Points {
+			switch {
+			case p.HasData && remaining[i] > lower:
+				sb.WriteString(cell("█"))
+			case within(ideal[i]):
+				sb.WriteString(cell("·"))
+			case p.HasData && within(scope[i]):
+				sb.WriteString(cell("─"))
+			default:
+				sb.WriteString(cell(" "))
+			}
+		}
+
+		boxLine(fmt.Sprintf("%6.0f │%s", upper, sb.String()))
+	}
+
+	boxLine(fmt.Sprintf("%6s └%s", "", strings.Repeat("─", len(bd.Points)*colWidth)))
+
+	startLabel := bd.Start.Format("01-02")
+	endLabel := bd.End.Format("01-02")
+	gap := len(bd.Points)*colWidth - len(startLabel) - len(endLabel)
+	if gap > 0 {
+		boxLine(fmt.Sprintf("%6s  %s%s%s", "", startLabel, strings.Repeat(" ", gap), endLabel))
+	}
+
+	if last, ok := lastWithData(bd); ok {
+		boxLine(fmt.Sprintf("   Remaining: %g %s of %g, ideal today: %.1f",
+			remaining[last], unit, scope[last], ideal[last]))
+	}
+
+	boxBottom()
+}
+
+// burndownSeries выбирает единицу графика: story points, а если их нет — часы остатка.
+func burndownSeries(bd *analysis.Burndown) (unit string, remaining, ideal, scope []float64) {
+	usePoints := false
+	for _, p := range bd.Points {
+		if p.ScopePoints > 0 {
+			usePoints = true
+			break
+		}
+	}
+
+	remaining = make([]float64, len(bd.Points))
+	ideal = make([]float64, len(bd.Points))
+	scope = make([]float64, len(bd.Points))
+
+	for i, p := range bd.Points {
+		if usePoints {
+			remaining[i], ideal[i], scope[i] = p.RemainingPoints, p.IdealPoints, p.ScopePoints
+		} else {
+			remaining[i], ideal[i] = p.RemainingWork, p.IdealWork
+		}
+	}
+
+	if usePoints {
+		return "story points", remaining, ideal, scope
+	}
+	return "hours", remaining, ideal, scope
+}
+
+func lastWithData(bd *analysis.Burndown) (int, bool) {
+	for i := len(bd.Points) - 1; i >= 0; i-- {
+		if bd.Points[i].HasData {
+			return i, true
+		}
+	}
+	return 0, false
+}
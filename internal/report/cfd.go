@@ -0,0 +1,142 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"scrum-eye/internal/analysis"
+	"scrum-eye/internal/domain"
+)
+
+// cfdBlocks — заливка слоёв в том же порядке, что и analysis.CFD.Categories.
+var cfdBlocks = map[domain.StateCategory]string{
+	domain.StateCompleted:  "█",
+	domain.StateResolved:   "▓",
+	domain.StateInProgress: "▒",
+	domain.StateProposed:   "░",
+	domain.StateUnknown:    "·",
+}
+
+// PrintCFD выводит накопительную диаграмму потока: по строке на день,
+// слои категорий состояний сложены слева направо.
+func PrintCFD(cfd *analysis.CFD) {
+	if cfd == nil || len(cfd.Points) == 0 {
+		return
+	}
+
+	maxTotal := 0
+	for _, p := range cfd.Points {
+		maxTotal = max(maxTotal, cfdTotal(p))
+	}
+
+	boxTop(" 🌊 Cumulative Flow")
+
+	if maxTotal == 0 {
+		boxLine("   No data yet: snapshots are saved on every run")
+		boxBottom()
+		return
+	}
+
+	barWidth := boxWidth - 16
+	for _, p := range cfd.Points {
+		if !p.HasData() {
+			boxLine(fmt.Sprintf("   %s │", p.Date.Format("01-02")))
+			continue
+		}
+
+		// округляем накопленную сумму, чтобы ширина полосы не плясала от округления слоёв
+		var sb strings.Builder
+		cum, drawn := 0, 0
+		for _, c := range cfd.Categories {
+			cum += p.Categories[c]
+			width := int(float64(cum)/float64(maxTotal)*float64(barWidth) + 0.5)
+			sb.WriteString(strings.Repeat(cfdBlocks[c], width-drawn))
+			drawn = width
+		}
+		boxLine(fmt.Sprintf("   %s │%s %d", p.Date.Format("01-02"), sb.String(), cfdTotal(p)))
+	}
+
+	boxSeparator()
+	legend := make([]string, 0, len(cfd.Categories))
+	for _, c := range cfd.Categories {
+		if cfdHasCategory(cfd, c) {
+			legend = append(legend, cfdBlocks[c]+" "+string(c))
+		}
+	}
+	boxLine("   " + strings.Join(legend, "  "))
+
+	// последний день с данными по конкретным колонкам доски — здесь видны узкие места
+	if last := lastCFDPoint(cfd); last != nil {
+		states := make([]string, 0, len(cfd.States))
+		for _, s := range cfd.States {
+			if n := last.States[s]; n > 0 {
+				states = append(states, fmt.Sprintf("%s %d", orNone(s), n))
+			}
+		}
+		boxLine("   " + last.Date.Format("01-02") + ": " + strings.Join(states, " · "))
+	}
+
+	boxBottom()
+}
+
+// WriteCFDCSV пишет данные CFD в «длинном» формате: date,category,state,count.
+func WriteCFDCSV(w io.Writer, cfd *analysis.CFD) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "category", "state", "count"}); err != nil {
+		return err
+	}
+
+	if cfd != nil {
+		for _, p := range cfd.Points {
+			if !p.HasData() {
+				continue
+			}
+			for _, s := range cfd.States {
+				row := []string{p.Date.Format("2006-01-02"), string(cfd.StateCategories[s]), s, strconv.Itoa(p.States[s])}
+				if err := cw.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCFDJSON пишет данные CFD в JSON.
+func WriteCFDJSON(w io.Writer, cfd *analysis.CFD) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cfd)
+}
+
+func cfdHasCategory(cfd *analysis.CFD, c domain.StateCategory) bool {
+	for _, p := range cfd.Points {
+		if p.Categories[c] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func cfdTotal(p analysis.CFDPoint) int {
+	total := 0
+	for _, n := range p.Categories {
+		total += n
+	}
+	return total
+}
+
+func lastCFDPoint(cfd *analysis.CFD) *analysis.CFDPoint {
+	for i := len(cfd.Points) - 1; i >= 0; i-- {
+		if cfd.Points[i].HasData() {
+			return &cfd.Points[i]
+		}
+	}
+	return nil
+}
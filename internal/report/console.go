@@ -2,6 +2,8 @@ package report
 
 import (
 	"fmt"
+	"scrum-eye/internal/analysis"
+	"scrum-eye/internal/diff"
 	"scrum-eye/internal/domain"
 	"strings"
 	"time"
@@ -17,52 +19,15 @@ func PrintCurrentSprint(project *domain.Project) {
 	width := 60
 	line := strings.Repeat("─", width)
 
-	now := time.Now()
-
-	startDateStr := "N/A"
-	if sprint.StartDate != nil {
-		startDateStr = sprint.StartDate.Format("2006-01-02")
-	}
-
-	endDateStr := "N/A"
-	daysLeftStr := "N/A"
-	if sprint.EndDate != nil {
-		endDateStr = sprint.EndDate.Format("2006-01-02")
-		daysLeft := int(sprint.EndDate.Sub(now).Hours() / 24)
-		daysLeftStr = fmt.Sprintf("%d", daysLeft)
-	}
-
-	// Подсчёт по типам
-	typeCounts := map[domain.WorkItemType]int{}
-	for _, wi := range sprint.WorkItems {
-		typeCounts[wi.Type]++
-	}
-
-	// Формируем строку сводки по типам
-	summaryParts := make([]string, 0)
-	total := len(sprint.WorkItems)
-	if total > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("Total: %d", total))
-	}
-	for _, t := range []domain.WorkItemType{
-		domain.WorkItemStory,
-		domain.WorkItemBug,
-		domain.WorkItemTask,
-		domain.WorkItemEpic,
-		domain.WorkItemFeature,
-		domain.WorkItemUnknown,
-	} {
-		if count, ok := typeCounts[t]; ok && count > 0 {
-			summaryParts = append(summaryParts, fmt.Sprintf("%s: %d", t, count))
-		}
-	}
-	summaryLine := "No work items"
-	if len(summaryParts) > 0 {
-		summaryLine = strings.Join(summaryParts, ", ")
-	}
+	startDateStr, endDateStr, daysLeftStr := sprintDates(sprint, time.Now())
+	summaryLine := workItemSummary(sprint.WorkItems)
 
 	fmt.Printf("\n┌%s┐\n", line)
-	fmt.Printf("│ %-*s│\n", width, " 🏃 Current Sprint")
+	title := " 🏃 Current Sprint"
+	if !sprint.IsCurrent() {
+		title = fmt.Sprintf(" 🗓  Sprint (%s)", sprint.TimeFrame)
+	}
+	fmt.Printf("│ %-*s│\n", width, title)
 	fmt.Printf("├%s┤\n", line)
 	fmt.Printf("│ %-*s│\n", width, fmt.Sprintf("   Name: %s", sprint.Name))
 	fmt.Printf("│ %-*s│\n", width, fmt.Sprintf("   Start Date: %s", startDateStr))
@@ -79,22 +44,188 @@ func PrintCurrentSprint(project *domain.Project) {
 	// Таблица с задачами
 	fmt.Printf("├%s┤\n", line)
 	fmt.Printf("│ %-*s│\n", width, "   Work Items List:")
-	fmt.Printf("│ %-*s│\n", width, "   ID    Type       Name")
-	fmt.Printf("│ %-*s│\n", width, "   ----  ---------- ---------------------------------")
+	fmt.Printf("│ %-*s│\n", width, "   ID    Type       State      Name")
+	fmt.Printf("│ %-*s│\n", width, "   ----  ---------- ---------- ----------------------")
 
 	for _, wi := range sprint.WorkItems {
 		idStr := fmt.Sprintf("%d", wi.ID)
 		typeStr := string(wi.Type)
-		// Оставляем место под отступы/ID/тип и немного под границу
-		nameWidth := width - len("   ") - 4 /*ID*/ - 2 /*spaces*/ - 10 /*Type*/ - 3
+		stateStr := truncate(wi.State, 10)
+		// Оставляем место под отступы/ID/тип/состояние и немного под границу
+		nameWidth := width - len("   ") - 4 /*ID*/ - 3 /*spaces*/ - 10 /*Type*/ - 10 /*State*/ - 3
 		name := truncate(wi.Name, nameWidth)
-		lineStr := fmt.Sprintf("   %-4s %-10s %s", idStr, typeStr, name)
+		lineStr := fmt.Sprintf("   %-4s %-10s %-10s %s", idStr, typeStr, stateStr, name)
 		fmt.Printf("│ %-*s│\n", width, lineStr)
 	}
 
 	fmt.Printf("└%s┘\n\n", line)
 }
 
+// PrintWarnings выводит предупреждения, накопленные при сборе и анализе данных.
+func PrintWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	for _, w := range warnings {
+		fmt.Printf("⚠️  %s\n", w)
+	}
+	fmt.Println()
+}
+
+// PrintDiff выводит изменения спринта с момента снимка-базы.
+func PrintDiff(res *diff.Result) {
+	if res == nil {
+		return
+	}
+
+	boxTop(fmt.Sprintf(" 🔄 What changed since %s", res.BaselineAt.Local().Format("2006-01-02 15:04")))
+
+	if res.SprintChanged {
+		boxLine(fmt.Sprintf("   Baseline was taken in another sprint: %s", res.BaselineSprint))
+	}
+	if res.IsEmpty() {
+		boxLine("   No changes")
+		boxBottom()
+		return
+	}
+
+	for _, wi := range res.Added {
+		boxLine(fmt.Sprintf("   + %d %s", wi.ID, wi.Name))
+	}
+	for _, wi := range res.Removed {
+		boxLine(fmt.Sprintf("   - %d %s", wi.ID, wi.Name))
+	}
+	for _, c := range res.StateChanges {
+		boxLine(fmt.Sprintf("   %d state: %s → %s", c.ID, orNone(c.From), orNone(c.To)))
+	}
+	for _, c := range res.Reassignments {
+		boxLine(fmt.Sprintf("   %d assignee: %s → %s", c.ID, orNone(c.From), orNone(c.To)))
+	}
+	for _, c := range res.EstimateChanges {
+		boxLine(fmt.Sprintf("   %d %s: %g → %g", c.ID, c.Field, c.From, c.To))
+	}
+	for _, c := range res.TitleChanges {
+		boxLine(fmt.Sprintf("   %d renamed: %s", c.ID, c.To))
+	}
+
+	boxBottom()
+}
+
+// PrintBuildHealth выводит состояние сборок по каждой конфигурации.
+func PrintBuildHealth(health []analysis.BuildHealth) {
+	if len(health) == 0 {
+		return
+	}
+
+	boxTop(" 🏗  Build Health")
+
+	for i, h := range health {
+		if i > 0 {
+			boxSeparator()
+		}
+
+		title := h.Name
+		if h.Branch != "" {
+			title = fmt.Sprintf("%s (%s)", h.Name, h.Branch)
+		}
+		boxLine("   " + title)
+
+		if h.Builds == 0 {
+			boxLine("   No builds")
+			continue
+		}
+
+		boxLine(fmt.Sprintf("   Status: %s   Success rate: %.0f%% (%d/%d)",
+			buildStatusLabel(h.CurrentStatus), h.SuccessRate*100, h.Successful, h.Builds))
+		if h.IsRed() {
+			boxLine(fmt.Sprintf("   Red for: %s", formatDuration(h.RedFor)))
+		}
+		boxLine(fmt.Sprintf("   MTTR: %s   Longest red: %d builds (%s)",
+			formatDurationOrNA(h.MTTR), h.LongestRedStreak, formatDurationOrNA(h.LongestRedDuration)))
+		boxLine(fmt.Sprintf("   Duration: avg %s, p90 %s %s",
+			formatDuration(h.AvgDuration), formatDuration(h.P90Duration), durationTrendLabel(h)))
+	}
+
+	boxBottom()
+}
+
+func buildStatusLabel(s domain.BuildStatus) string {
+	switch s {
+	case domain.BuildSuccess:
+		return "🟢 green"
+	case domain.BuildFailure:
+		return "🔴 red"
+	default:
+		return "⚪ unknown"
+	}
+}
+
+func durationTrendLabel(h analysis.BuildHealth) string {
+	if h.PrevAvgDuration == 0 {
+		return ""
+	}
+	arrow := "▲"
+	if h.DurationTrend < 0 {
+		arrow = "▼"
+	}
+	return fmt.Sprintf("(%s %+.0f%% vs prev)", arrow, h.DurationTrend*100)
+}
+
+// formatDuration форматирует длительность в виде 1d 2h, 3h 15m или 4m 30s.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	}
+}
+
+func formatDurationOrNA(d time.Duration) string {
+	if d == 0 {
+		return "N/A"
+	}
+	return formatDuration(d)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+const boxWidth = 60
+
+func boxTop(title string) {
+	line := strings.Repeat("─", boxWidth)
+	fmt.Printf("\n┌%s┐\n", line)
+	fmt.Printf("│ %-*s│\n", boxWidth, title)
+	fmt.Printf("├%s┤\n", line)
+}
+
+func boxSeparator() {
+	fmt.Printf("├%s┤\n", strings.Repeat("─", boxWidth))
+}
+
+// boxLine выводит строку внутри блока, обрезая её по ширине.
+func boxLine(s string) {
+	fmt.Printf("│ %-*s│\n", boxWidth, truncate(s, boxWidth-1))
+}
+
+func boxBottom() {
+	fmt.Printf("└%s┘\n\n", strings.Repeat("─", boxWidth))
+}
+
 // truncate обрезает строку до max символов и добавляет многоточие при необходимости.
 func truncate(s string, max int) string {
 	if max <= 0 {
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"scrum-eye/internal/analysis"
+	"scrum-eye/internal/diff"
+	"scrum-eye/internal/domain"
+)
+
+// SchemaVersion — версия машинно-читаемого формата отчёта.
+// Увеличивается при несовместимых изменениях полей; новые поля версию не меняют.
+const SchemaVersion = 1
+
+// Document — всё, что попадает в отчёт: собранные данные и результаты анализа.
+// Каждый результат анализа выводится во всех форматах: console, markdown, html, json и ndjson.
+type Document struct {
+	SchemaVersion int                    `json:"schemaVersion"`
+	GeneratedAt   time.Time              `json:"generatedAt"`
+	Team          string                 `json:"team"`
+	Project       *domain.Project        `json:"project"`
+	Diff          *diff.Result           `json:"diff,omitempty"`
+	Burndown      *analysis.Burndown     `json:"burndown,omitempty"`
+	CFD           *analysis.CFD          `json:"cfd,omitempty"`
+	Scope         *analysis.ScopeChange  `json:"scope,omitempty"`
+	Velocity      *analysis.Velocity     `json:"velocity,omitempty"`
+	Forecast      *analysis.Forecast     `json:"forecast,omitempty"`
+	Flow          *analysis.Flow         `json:"flow,omitempty"`
+	Aging         *analysis.AgingWIP     `json:"aging,omitempty"`
+	WIP           *analysis.WIPReport    `json:"wip,omitempty"`
+	Builds        []analysis.BuildHealth `json:"builds,omitempty"`
+	Warnings      []string               `json:"warnings"`
+}
+
+// NewDocument создаёт документ отчёта для команды.
+func NewDocument(team string, project *domain.Project, generatedAt time.Time) *Document {
+	doc := &Document{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   generatedAt,
+		Team:          team,
+		Project:       project,
+		Warnings:      []string{},
+	}
+	if project != nil {
+		doc.Warnings = append(doc.Warnings, project.Warnings...)
+	}
+	return doc
+}
+
+type Format string
+
+const (
+	FormatConsole  Format = "console"
+	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// ParseFormat разбирает значение --format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "", FormatConsole:
+		return FormatConsole, nil
+	case FormatJSON, FormatNDJSON, FormatMarkdown, FormatHTML:
+		return f, nil
+	case "md":
+		return FormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("неизвестный формат %q (console, json, ndjson, markdown, html)", s)
+	}
+}
+
+// PrintConsole выводит документ в консоль в текстовом виде.
+func PrintConsole(doc *Document) {
+	PrintCurrentSprint(doc.Project)
+	PrintWIP(doc.WIP)
+	PrintAgingWIP(doc.Aging)
+	PrintBurndown(doc.Burndown)
+	PrintCFD(doc.CFD)
+	PrintScope(doc.Scope)
+	PrintForecast(doc.Forecast)
+	PrintVelocity(doc.Velocity)
+	PrintFlow(doc.Flow)
+	PrintBuildHealth(doc.Builds)
+	PrintDiff(doc.Diff)
+	PrintWarnings(doc.Warnings)
+}
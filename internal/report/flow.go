@@ -0,0 +1,34 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"scrum-eye/internal/analysis"
+)
+
+// PrintFlow выводит cycle time и lead time по типам элементов.
+func PrintFlow(f *analysis.Flow) {
+	if f == nil {
+		return
+	}
+
+	boxTop(fmt.Sprintf(" ⏱  Flow (%d completed items, days)", len(f.Items)))
+	boxLine(fmt.Sprintf("   %-10s %-6s %5s %5s %5s   %-6s %5s %5s", "Type", "Cycle", "p50", "p85", "p95", "Lead", "p50", "p85"))
+	boxLine(fmt.Sprintf("   %-10s %-6s %5s %5s %5s   %-6s %5s %5s",
+		strings.Repeat("-", 10), "------", "-----", "-----", "-----", "------", "-----", "-----"))
+
+	for _, t := range f.ByType {
+		boxLine(flowRow(string(t.Type), t.CycleTime, t.LeadTime))
+	}
+	boxSeparator()
+	boxLine(flowRow("All", f.CycleTime, f.LeadTime))
+	boxBottom()
+}
+
+func flowRow(name string, cycle, lead analysis.FlowStats) string {
+	return fmt.Sprintf("   %-10s %-6s %5.1f %5.1f %5.1f   %-6s %5.1f %5.1f",
+		truncate(name, 10),
+		fmt.Sprintf("n=%d", cycle.Count), cycle.P50, cycle.P85, cycle.P95,
+		fmt.Sprintf("n=%d", lead.Count), lead.P50, lead.P85)
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"fmt"
+
+	"scrum-eye/internal/analysis"
+)
+
+// PrintForecast выводит вероятность успеть к концу спринта и процентили даты завершения.
+func PrintForecast(f *analysis.Forecast) {
+	if f == nil {
+		return
+	}
+
+	boxTop(" 🎲 Will we make it?")
+
+	if f.RemainingItems == 0 {
+		boxLine("   All backlog items are done 🎉")
+		boxBottom()
+		return
+	}
+
+	boxLine(fmt.Sprintf("   %d items left, chance to finish by %s: %.0f%%",
+		f.RemainingItems, forecastDeadline(f), f.Probability*100))
+	boxLine(fmt.Sprintf("   50%%: %s   85%%: %s   95%%: %s",
+		f.P50.Format("2006-01-02"), f.P85.Format("2006-01-02"), f.P95.Format("2006-01-02")))
+	boxLine(fmt.Sprintf("   Based on %d days of throughput, %d simulations", f.SampleDays, f.Trials))
+	boxBottom()
+}
+
+// forecastDeadline — дата, к которой считается вероятность успеть.
+func forecastDeadline(f *analysis.Forecast) string {
+	if f.EndDate == nil {
+		return "sprint end"
+	}
+	return f.EndDate.Format("2006-01-02")
+}
@@ -0,0 +1,343 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"scrum-eye/internal/analysis"
+	"scrum-eye/internal/domain"
+)
+
+//go:embed templates/report.html
+var htmlTemplateSource string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration":      formatDuration,
+	"durationOrNA":  formatDurationOrNA,
+	"points":        formatPoints,
+	"orNone":        orNone,
+	"percent":       func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) },
+	"buildStatus":   buildStatusLabel,
+	"durationTrend": durationTrendLabel,
+	"stateClass":    stateClass,
+	"dateTime":      func(t time.Time) string { return t.Local().Format("2006-01-02 15:04") },
+	"date":          func(t time.Time) string { return t.Format("2006-01-02") },
+	"oneDecimal":    func(v float64) string { return fmt.Sprintf("%.1f", v) },
+	"wipTeam":       wipTeamLabel,
+	"wipPerson":     wipPersonLabel,
+	"deadline":      forecastDeadline,
+	"trend":         trendLabel,
+	"sparkline":     func(v *analysis.Velocity) string { return sparkline(velocityCompleted(v)) },
+	"approximate":   approximateCommitment,
+	"anyApproximate": func(v *analysis.Velocity) bool {
+		for _, s := range v.Sprints {
+			if approximateCommitment(s) {
+				return true
+			}
+		}
+		return false
+	},
+}).Parse(htmlTemplateSource))
+
+// htmlGroup — группа рабочих элементов (по состоянию или исполнителю).
+type htmlGroup struct {
+	Title    string
+	Category domain.StateCategory
+	Points   float64
+	Items    []domain.WorkItem
+}
+
+// htmlBarSegment — сегмент SVG-полосы распределения по категориям состояний.
+type htmlBarSegment struct {
+	Category domain.StateCategory
+	Count    int
+	X        float64
+	Width    float64
+}
+
+// htmlChart — данные SVG-графика сгорания; координаты уже пересчитаны в пиксели.
+type htmlChart struct {
+	Width     float64
+	Height    float64
+	ViewBox   string
+	Unit      string
+	Max       float64
+	Remaining string
+	Ideal     string
+	Scope     string
+	Start     string
+	End       string
+}
+
+// htmlCFDSegment — слой одной категории в столбце CFD.
+type htmlCFDSegment struct {
+	Category domain.StateCategory
+	Count    int
+	Y        float64
+	Height   float64
+}
+
+// htmlCFDColumn — столбец CFD за один день; у дней без данных сегментов нет.
+type htmlCFDColumn struct {
+	Date     string
+	X        float64
+	Width    float64
+	Total    int
+	Segments []htmlCFDSegment
+}
+
+// htmlCFDChart — данные SVG-диаграммы накопительного потока.
+type htmlCFDChart struct {
+	Width      float64
+	Height     float64
+	ViewBox    string
+	Max        int
+	Columns    []htmlCFDColumn
+	Categories []domain.StateCategory
+	Start      string
+	End        string
+}
+
+type htmlView struct {
+	*Document
+	Sprint       *domain.Sprint
+	Start        string
+	End          string
+	DaysLeft     string
+	Summary      string
+	ByState      []htmlGroup
+	ByAssignee   []htmlGroup
+	Distribution []htmlBarSegment
+	Burndown     *htmlChart
+	CFDChart     *htmlCFDChart
+}
+
+// WriteHTML пишет самодостаточный HTML-отчёт: стили и графики встроены, внешних ресурсов нет.
+func WriteHTML(w io.Writer, doc *Document) error {
+	view := htmlView{Document: doc}
+
+	if doc.Project != nil && doc.Project.CurrentSprint != nil {
+		sprint := doc.Project.CurrentSprint
+		view.Sprint = sprint
+		view.Start, view.End, view.DaysLeft = sprintDates(sprint, doc.GeneratedAt)
+		view.Summary = workItemSummary(sprint.WorkItems)
+		view.ByState = groupByState(sprint.WorkItems)
+		view.ByAssignee = groupByAssignee(sprint.WorkItems)
+		view.Distribution = stateDistribution(sprint.WorkItems, 1000)
+	}
+	view.Burndown = burndownChart(doc.Burndown, 1000, 240)
+	view.CFDChart = cfdChart(doc.CFD, 1000, 240)
+
+	return htmlTemplate.Execute(w, view)
+}
+
+// stateCategoryOrder — порядок категорий состояний на доске.
+var stateCategoryOrder = []domain.StateCategory{
+	domain.StateProposed,
+	domain.StateInProgress,
+	domain.StateResolved,
+	domain.StateCompleted,
+	domain.StateRemoved,
+	domain.StateUnknown,
+}
+
+func categoryRank(c domain.StateCategory) int {
+	for i, v := range stateCategoryOrder {
+		if v == c {
+			return i
+		}
+	}
+	return len(stateCategoryOrder)
+}
+
+func groupByState(items []domain.WorkItem) []htmlGroup {
+	groups := map[string]*htmlGroup{}
+	for _, wi := range items {
+		key := wi.State
+		if key == "" {
+			key = string(wi.StateCategory)
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &htmlGroup{Title: key, Category: wi.StateCategory}
+			groups[key] = g
+		}
+		g.Items = append(g.Items, wi)
+		g.Points += wi.StoryPoints
+	}
+
+	result := make([]htmlGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		ri, rj := categoryRank(result[i].Category), categoryRank(result[j].Category)
+		if ri != rj {
+			return ri < rj
+		}
+		return result[i].Title < result[j].Title
+	})
+	return result
+}
+
+func groupByAssignee(items []domain.WorkItem) []htmlGroup {
+	groups := map[string]*htmlGroup{}
+	for _, wi := range items {
+		key := wi.AssignedTo
+		if key == "" {
+			key = analysis.Unassigned
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &htmlGroup{Title: key}
+			groups[key] = g
+		}
+		g.Items = append(g.Items, wi)
+		g.Points += wi.StoryPoints
+	}
+
+	result := make([]htmlGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Slice(g.Items, func(i, j int) bool {
+			return categoryRank(g.Items[i].StateCategory) < categoryRank(g.Items[j].StateCategory)
+		})
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Title) < strings.ToLower(result[j].Title)
+	})
+	return result
+}
+
+// stateDistribution раскладывает элементы по категориям в полосу шириной width.
+func stateDistribution(items []domain.WorkItem, width float64) []htmlBarSegment {
+	if len(items) == 0 {
+		return nil
+	}
+
+	counts := map[domain.StateCategory]int{}
+	for _, wi := range items {
+		counts[wi.StateCategory]++
+	}
+
+	segments := make([]htmlBarSegment, 0, len(counts))
+	x := 0.0
+	for _, c := range stateCategoryOrder {
+		if counts[c] == 0 {
+			continue
+		}
+		w := width * float64(counts[c]) / float64(len(items))
+		segments = append(segments, htmlBarSegment{Category: c, Count: counts[c], X: x, Width: w})
+		x += w
+	}
+	return segments
+}
+
+// stateClass — CSS-класс для категории состояния.
+func stateClass(c domain.StateCategory) string {
+	return "st-" + strings.ToLower(string(c))
+}
+
+// burndownChart переводит сгорание в ломаные SVG. Дни без снимков пропускаются.
+func burndownChart(bd *analysis.Burndown, width, height float64) *htmlChart {
+	if bd == nil || len(bd.Points) == 0 {
+		return nil
+	}
+
+	unit, remaining, ideal, scope := burndownSeries(bd)
+
+	maxV := 0.0
+	for i := range remaining {
+		maxV = max(maxV, remaining[i], ideal[i], scope[i])
+	}
+	if maxV == 0 {
+		return nil
+	}
+
+	step := width
+	if len(bd.Points) > 1 {
+		step = width / float64(len(bd.Points)-1)
+	}
+	point := func(i int, v float64) string {
+		return fmt.Sprintf("%.1f,%.1f ", float64(i)*step, height-v/maxV*height)
+	}
+
+	var rem, idl, scp strings.Builder
+	for i, p := range bd.Points {
+		idl.WriteString(point(i, ideal[i]))
+		if !p.HasData {
+			continue
+		}
+		rem.WriteString(point(i, remaining[i]))
+		if scope[i] > 0 {
+			scp.WriteString(point(i, scope[i]))
+		}
+	}
+
+	return &htmlChart{
+		Width:     width,
+		Height:    height,
+		ViewBox:   fmt.Sprintf("-40 -10 %.0f %.0f", width+60, height+40),
+		Unit:      unit,
+		Max:       maxV,
+		Remaining: rem.String(),
+		Ideal:     idl.String(),
+		Scope:     scp.String(),
+		Start:     bd.Start.Format("2006-01-02"),
+		End:       bd.End.Format("2006-01-02"),
+	}
+}
+
+// cfdChart складывает категории состояний в столбцы по дням снизу вверх.
+func cfdChart(cfd *analysis.CFD, width, height float64) *htmlCFDChart {
+	if cfd == nil || len(cfd.Points) == 0 {
+		return nil
+	}
+
+	maxTotal := 0
+	for _, p := range cfd.Points {
+		maxTotal = max(maxTotal, cfdTotal(p))
+	}
+	if maxTotal == 0 {
+		return nil
+	}
+
+	step := width / float64(len(cfd.Points))
+	chart := &htmlCFDChart{
+		Width:   width,
+		Height:  height,
+		ViewBox: fmt.Sprintf("-40 -10 %.0f %.0f", width+60, height+40),
+		Max:     maxTotal,
+		Start:   cfd.Start.Format("2006-01-02"),
+		End:     cfd.End.Format("2006-01-02"),
+	}
+	for _, c := range cfd.Categories {
+		if cfdHasCategory(cfd, c) {
+			chart.Categories = append(chart.Categories, c)
+		}
+	}
+
+	for i, p := range cfd.Points {
+		col := htmlCFDColumn{Date: p.Date.Format("2006-01-02"), X: float64(i)*step + 1, Width: step - 2}
+		if p.HasData() {
+			col.Total = cfdTotal(p)
+			y := height
+			for _, c := range cfd.Categories {
+				n := p.Categories[c]
+				if n == 0 {
+					continue
+				}
+				h := float64(n) / float64(maxTotal) * height
+				y -= h
+				col.Segments = append(col.Segments, htmlCFDSegment{Category: c, Count: n, Y: y, Height: h})
+			}
+		}
+		chart.Columns = append(chart.Columns, col)
+	}
+	return chart
+}
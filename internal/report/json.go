@@ -0,0 +1,123 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// WriteJSON пишет документ одним JSON-объектом.
+func WriteJSON(w io.Writer, doc *Document) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// ndjsonRecord — одна строка NDJSON-вывода. Type определяет содержимое Data:
+// meta, sprint, workItem, historySprint, historyWorkItem, build, wip, aging, burndown, cfd,
+// scope, velocity, forecast, flow, diff, warning.
+type ndjsonRecord struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Type          string `json:"type"`
+	Team          string `json:"team"`
+	Data          any    `json:"data"`
+}
+
+type ndjsonMeta struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// ndjsonHistoryItem — элемент прошлого спринта с ID этого спринта.
+type ndjsonHistoryItem struct {
+	SprintID string `json:"sprintId"`
+	domain.WorkItem
+}
+
+// WriteNDJSON пишет документ построчно: по записи на спринт, рабочий элемент,
+// конфигурацию сборки, каждый результат анализа и предупреждение,
+// чтобы вывод было удобно фильтровать через jq.
+func WriteNDJSON(w io.Writer, doc *Document) error {
+	enc := json.NewEncoder(w)
+	write := func(typ string, data any) error {
+		return enc.Encode(ndjsonRecord{
+			SchemaVersion: doc.SchemaVersion,
+			Type:          typ,
+			Team:          doc.Team,
+			Data:          data,
+		})
+	}
+
+	if err := write("meta", ndjsonMeta{GeneratedAt: doc.GeneratedAt}); err != nil {
+		return err
+	}
+
+	if doc.Project != nil && doc.Project.CurrentSprint != nil {
+		sprint := *doc.Project.CurrentSprint
+		items := sprint.WorkItems
+		sprint.WorkItems = nil
+
+		if err := write("sprint", sprint); err != nil {
+			return err
+		}
+		for _, wi := range items {
+			if err := write("workItem", wi); err != nil {
+				return err
+			}
+		}
+	}
+
+	if doc.Project != nil {
+		for _, sprint := range doc.Project.SprintHistory {
+			items := sprint.WorkItems
+			sprint.WorkItems = nil
+
+			if err := write("historySprint", sprint); err != nil {
+				return err
+			}
+			for _, wi := range items {
+				if err := write("historyWorkItem", ndjsonHistoryItem{SprintID: sprint.ID, WorkItem: wi}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, b := range doc.Builds {
+		if err := write("build", b); err != nil {
+			return err
+		}
+	}
+
+	// nil-анализы (нет данных) не пишутся
+	for _, a := range []struct {
+		typ  string
+		data any
+		ok   bool
+	}{
+		{"wip", doc.WIP, doc.WIP != nil},
+		{"aging", doc.Aging, doc.Aging != nil},
+		{"burndown", doc.Burndown, doc.Burndown != nil},
+		{"cfd", doc.CFD, doc.CFD != nil},
+		{"scope", doc.Scope, doc.Scope != nil},
+		{"velocity", doc.Velocity, doc.Velocity != nil},
+		{"forecast", doc.Forecast, doc.Forecast != nil},
+		{"flow", doc.Flow, doc.Flow != nil},
+		{"diff", doc.Diff, doc.Diff != nil},
+	} {
+		if !a.ok {
+			continue
+		}
+		if err := write(a.typ, a.data); err != nil {
+			return err
+		}
+	}
+	for _, msg := range doc.Warnings {
+		if err := write("warning", msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
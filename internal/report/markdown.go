@@ -0,0 +1,373 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"scrum-eye/internal/analysis"
+	"scrum-eye/internal/diff"
+	"scrum-eye/internal/domain"
+)
+
+// WriteMarkdown пишет отчёт в формате GitHub/Azure DevOps wiki Markdown.
+func WriteMarkdown(w io.Writer, doc *Document) error {
+	bw := bufio.NewWriter(w)
+
+	writeMarkdownSprint(bw, doc)
+	writeMarkdownWIP(bw, doc.WIP)
+	writeMarkdownAging(bw, doc.Aging)
+	writeMarkdownBurndown(bw, doc.Burndown)
+	writeMarkdownCFD(bw, doc.CFD)
+	writeMarkdownScope(bw, doc.Scope)
+	writeMarkdownForecast(bw, doc.Forecast)
+	writeMarkdownVelocity(bw, doc.Velocity)
+	writeMarkdownFlow(bw, doc.Flow)
+	writeMarkdownBuilds(bw, doc.Builds)
+	writeMarkdownDiff(bw, doc.Diff)
+	writeMarkdownWarnings(bw, doc.Warnings)
+
+	return bw.Flush()
+}
+
+func writeMarkdownSprint(w io.Writer, doc *Document) {
+	if doc.Project == nil || doc.Project.CurrentSprint == nil {
+		fmt.Fprintln(w, "_No sprint information available_")
+		fmt.Fprintln(w)
+		return
+	}
+
+	sprint := doc.Project.CurrentSprint
+	start, end, daysLeft := sprintDates(sprint, doc.GeneratedAt)
+
+	fmt.Fprintf(w, "## 🏃 %s — %s\n\n", mdEscape(doc.Team), mdEscape(sprint.Name))
+	fmt.Fprintf(w, "- **Start Date:** %s\n", start)
+	fmt.Fprintf(w, "- **End Date:** %s\n", end)
+	fmt.Fprintf(w, "- **Days Left:** %s\n", daysLeft)
+	fmt.Fprintf(w, "- **Work Items:** %s\n\n", workItemSummary(sprint.WorkItems))
+
+	if len(sprint.WorkItems) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "| ID | Type | State | Assigned To | SP | Title |")
+	fmt.Fprintln(w, "|---:|------|-------|-------------|---:|-------|")
+	for _, wi := range sprint.WorkItems {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n",
+			mdWorkItemLink(wi), wi.Type, mdCell(wi.State), mdCell(wi.AssignedTo),
+			formatPoints(wi.StoryPoints), mdCell(wi.Name))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownWIP(w io.Writer, wip *analysis.WIPReport) {
+	if wip == nil || len(wip.People) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "### 🚧 Work in Progress: %s\n\n", wipTeamLabel(wip))
+	fmt.Fprintln(w, "| Assignee | In progress | Open SP |")
+	fmt.Fprintln(w, "|----------|------------:|--------:|")
+	for _, p := range wip.People {
+		fmt.Fprintf(w, "| %s | %s | %g |\n", mdCell(p.Name), wipPersonLabel(wip, p), p.OpenStoryPoints)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownAging(w io.Writer, a *analysis.AgingWIP) {
+	if a == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "### 🐢 Aging WIP (older than %.1f days)\n\n", a.ThresholdDays)
+	if len(a.Items) == 0 {
+		fmt.Fprintln(w, "Nothing is stuck 👍")
+		fmt.Fprintln(w)
+		return
+	}
+
+	fmt.Fprintln(w, "| ID | Age, days | State | Assigned To | Title |")
+	fmt.Fprintln(w, "|---:|----------:|-------|-------------|-------|")
+	for _, it := range a.Items {
+		fmt.Fprintf(w, "| %s | %.0f | %s | %s | %s |\n",
+			mdWorkItemLink(domain.WorkItem{ID: it.ID, URL: it.URL}), it.AgeDays,
+			mdCell(it.State), mdCell(it.AssignedTo), mdCell(it.Name))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownBurndown(w io.Writer, bd *analysis.Burndown) {
+	if bd == nil || len(bd.Points) == 0 {
+		return
+	}
+
+	unit, remaining, ideal, scope := burndownSeries(bd)
+
+	fmt.Fprintf(w, "### 📉 Burndown (%s)\n\n", unit)
+	fmt.Fprintln(w, "| Date | Remaining | Ideal | Scope |")
+	fmt.Fprintln(w, "|------|----------:|------:|------:|")
+	for i, p := range bd.Points {
+		if !p.HasData {
+			fmt.Fprintf(w, "| %s | — | %.1f | — |\n", p.Date.Format("2006-01-02"), ideal[i])
+			continue
+		}
+		fmt.Fprintf(w, "| %s | %g | %.1f | %s |\n",
+			p.Date.Format("2006-01-02"), remaining[i], ideal[i], orDash(formatPoints(scope[i])))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownCFD(w io.Writer, cfd *analysis.CFD) {
+	if cfd == nil || len(cfd.Points) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "### 🌊 Cumulative Flow")
+	fmt.Fprintln(w)
+
+	header, align := "| Date |", "|------|"
+	for _, c := range cfd.Categories {
+		header += " " + string(c) + " |"
+		align += "---:|"
+	}
+	fmt.Fprintln(w, header+" Total |")
+	fmt.Fprintln(w, align+"------:|")
+
+	for _, p := range cfd.Points {
+		if !p.HasData() {
+			continue
+		}
+		row := "| " + p.Date.Format("2006-01-02") + " |"
+		for _, c := range cfd.Categories {
+			row += fmt.Sprintf(" %d |", p.Categories[c])
+		}
+		fmt.Fprintf(w, "%s %d |\n", row, cfdTotal(p))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownScope(w io.Writer, sc *analysis.ScopeChange) {
+	if sc == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "### 🎯 Scope since %s\n\n", sc.CommittedAt.Local().Format("2006-01-02 15:04"))
+	fmt.Fprintf(w, "- **Committed:** %g SP (%d items)\n", sc.CommittedPoints, sc.CommittedItems)
+	fmt.Fprintf(w, "- **Added:** %g SP (%d items)\n", sc.AddedPoints, len(sc.Added))
+	fmt.Fprintf(w, "- **Removed:** %g SP (%d items)\n", sc.RemovedPoints, len(sc.Removed))
+	fmt.Fprintf(w, "- **Unplanned work:** %.0f%%\n\n", sc.UnplannedPercent)
+
+	for _, it := range sc.Added {
+		by := ""
+		if it.By != "" {
+			by = " — added by " + mdEscape(it.By)
+		}
+		fmt.Fprintf(w, "- ➕ %s %s %s%s\n", mdWorkItemLink(domain.WorkItem{ID: it.ID, URL: it.URL}),
+			mdEscape(it.Name), formatPoints(it.StoryPoints), by)
+	}
+	for _, it := range sc.Removed {
+		fmt.Fprintf(w, "- ➖ %s %s %s\n", mdWorkItemLink(domain.WorkItem{ID: it.ID, URL: it.URL}),
+			mdEscape(it.Name), formatPoints(it.StoryPoints))
+	}
+	if len(sc.Added) > 0 || len(sc.Removed) > 0 {
+		fmt.Fprintln(w)
+	}
+}
+
+func writeMarkdownForecast(w io.Writer, f *analysis.Forecast) {
+	if f == nil {
+		return
+	}
+
+	fmt.Fprintln(w, "### 🎲 Will we make it?")
+	fmt.Fprintln(w)
+
+	if f.RemainingItems == 0 {
+		fmt.Fprintln(w, "All backlog items are done 🎉")
+		fmt.Fprintln(w)
+		return
+	}
+
+	fmt.Fprintf(w, "- **Items left:** %d\n", f.RemainingItems)
+	fmt.Fprintf(w, "- **Chance to finish by %s:** %.0f%%\n", forecastDeadline(f), f.Probability*100)
+	fmt.Fprintf(w, "- **Finish date:** 50%% %s · 85%% %s · 95%% %s\n",
+		f.P50.Format("2006-01-02"), f.P85.Format("2006-01-02"), f.P95.Format("2006-01-02"))
+	fmt.Fprintf(w, "- **Based on:** %d days of throughput, %d simulations\n\n", f.SampleDays, f.Trials)
+}
+
+func writeMarkdownVelocity(w io.Writer, v *analysis.Velocity) {
+	if v == nil || len(v.Sprints) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "### 🚀 Velocity")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Sprint | Committed | Done | Say/Do |")
+	fmt.Fprintln(w, "|--------|----------:|-----:|-------:|")
+
+	approximate := false
+	for _, s := range v.Sprints {
+		mark := ""
+		if approximateCommitment(s) {
+			mark, approximate = " \\*", true
+		}
+		fmt.Fprintf(w, "| %s | %g (%d) | %g (%d) | %.0f%%%s |\n", mdCell(s.Name),
+			s.CommittedPoints, s.CommittedItems, s.CompletedPoints, s.CompletedItems, s.SayDo*100, mark)
+	}
+	fmt.Fprintln(w)
+
+	if approximate {
+		fmt.Fprintln(w, "\\* commitment taken from sprint contents at the end")
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "- **Average:** %.1f ± %.1f SP, %.1f ± %.1f items\n",
+		v.AveragePoints, v.StdDevPoints, v.AverageItems, v.StdDevItems)
+	fmt.Fprintf(w, "- **Say/Do:** %.0f%%\n", v.AverageSayDo*100)
+	fmt.Fprintf(w, "- **Trend:** %s %s\n\n", sparkline(velocityCompleted(v)), trendLabel(v.Trend))
+}
+
+func writeMarkdownFlow(w io.Writer, f *analysis.Flow) {
+	if f == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "### ⏱ Flow (%d completed items, days)\n\n", len(f.Items))
+	fmt.Fprintln(w, "| Type | Cycle n | Cycle p50 | Cycle p85 | Cycle p95 | Lead n | Lead p50 | Lead p85 | Lead p95 |")
+	fmt.Fprintln(w, "|------|--------:|----------:|----------:|----------:|-------:|---------:|---------:|---------:|")
+	rows := append([]analysis.TypeFlow{}, f.ByType...)
+	rows = append(rows, analysis.TypeFlow{Type: "**All**", CycleTime: f.CycleTime, LeadTime: f.LeadTime})
+	for _, t := range rows {
+		fmt.Fprintf(w, "| %s | %d | %.1f | %.1f | %.1f | %d | %.1f | %.1f | %.1f |\n", t.Type,
+			t.CycleTime.Count, t.CycleTime.P50, t.CycleTime.P85, t.CycleTime.P95,
+			t.LeadTime.Count, t.LeadTime.P50, t.LeadTime.P85, t.LeadTime.P95)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownBuilds(w io.Writer, health []analysis.BuildHealth) {
+	if len(health) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "### 🏗 Build Health")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Build | Status | Red for | Success rate | MTTR | Longest red | Avg / p90 duration |")
+	fmt.Fprintln(w, "|-------|--------|---------|-------------:|------|-------------|--------------------|")
+	for _, h := range health {
+		name := h.Name
+		if h.Branch != "" {
+			name = fmt.Sprintf("%s (%s)", h.Name, h.Branch)
+		}
+
+		redFor := "—"
+		if h.IsRed() {
+			redFor = formatDuration(h.RedFor)
+		}
+
+		fmt.Fprintf(w, "| %s | %s | %s | %.0f%% (%d/%d) | %s | %d builds (%s) | %s / %s %s |\n",
+			mdCell(name), buildStatusLabel(h.CurrentStatus), redFor,
+			h.SuccessRate*100, h.Successful, h.Builds,
+			formatDurationOrNA(h.MTTR), h.LongestRedStreak, formatDurationOrNA(h.LongestRedDuration),
+			formatDuration(h.AvgDuration), formatDuration(h.P90Duration), durationTrendLabel(h))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownDiff(w io.Writer, res *diff.Result) {
+	if res == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "### 🔄 What changed since %s\n\n", res.BaselineAt.Local().Format("2006-01-02 15:04"))
+
+	if res.SprintChanged {
+		fmt.Fprintf(w, "_Baseline was taken in another sprint: %s_\n\n", mdEscape(res.BaselineSprint))
+	}
+	if res.IsEmpty() {
+		fmt.Fprintln(w, "No changes.")
+		fmt.Fprintln(w)
+		return
+	}
+
+	for _, wi := range res.Added {
+		fmt.Fprintf(w, "- ➕ %s %s\n", mdWorkItemLink(wi), mdEscape(wi.Name))
+	}
+	for _, wi := range res.Removed {
+		fmt.Fprintf(w, "- ➖ %s %s\n", mdWorkItemLink(wi), mdEscape(wi.Name))
+	}
+	for _, c := range res.StateChanges {
+		fmt.Fprintf(w, "- #%d %s: **%s → %s**\n", c.ID, mdEscape(c.Name), orNone(c.From), orNone(c.To))
+	}
+	for _, c := range res.Reassignments {
+		fmt.Fprintf(w, "- #%d %s: assignee %s → %s\n", c.ID, mdEscape(c.Name), orNone(c.From), orNone(c.To))
+	}
+	for _, c := range res.EstimateChanges {
+		fmt.Fprintf(w, "- #%d %s: %s %g → %g\n", c.ID, mdEscape(c.Name), c.Field, c.From, c.To)
+	}
+	for _, c := range res.TitleChanges {
+		fmt.Fprintf(w, "- #%d renamed: ~~%s~~ → %s\n", c.ID, mdEscape(c.From), mdEscape(c.To))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownWarnings(w io.Writer, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "### ⚠️ Warnings")
+	fmt.Fprintln(w)
+	for _, msg := range warnings {
+		fmt.Fprintf(w, "- %s\n", mdEscape(msg))
+	}
+	fmt.Fprintln(w)
+}
+
+// mdWorkItemLink — ссылка на рабочий элемент, если известен его адрес.
+func mdWorkItemLink(wi domain.WorkItem) string {
+	if wi.URL == "" {
+		return fmt.Sprintf("#%d", wi.ID)
+	}
+	return fmt.Sprintf("[#%d](%s)", wi.ID, wi.URL)
+}
+
+var mdReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// mdEscape экранирует символы разметки в произвольном тексте.
+func mdEscape(s string) string {
+	return mdReplacer.Replace(s)
+}
+
+// mdCell экранирует текст для ячейки таблицы.
+func mdCell(s string) string {
+	if s == "" {
+		return "—"
+	}
+	s = strings.ReplaceAll(mdEscape(s), "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// orDash заменяет пустое значение прочерком.
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+func formatPoints(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,182 @@
+package report
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"scrum-eye/internal/analysis"
+)
+
+// Portfolio — сводный обзор нескольких команд: по строке на команду.
+type Portfolio struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	GeneratedAt   time.Time `json:"generatedAt"`
+	// Group — группа команд из global.yaml; пусто, если собраны все команды.
+	Group string                   `json:"group,omitempty"`
+	Teams []analysis.PortfolioTeam `json:"teams"`
+}
+
+// NewPortfolio создаёт портфельный обзор. Команды сортируются от самых рискованных.
+func NewPortfolio(group string, teams []analysis.PortfolioTeam, generatedAt time.Time) *Portfolio {
+	sorted := append([]analysis.PortfolioTeam{}, teams...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].RiskScore != sorted[j].RiskScore {
+			return sorted[i].RiskScore > sorted[j].RiskScore
+		}
+		return sorted[i].Team < sorted[j].Team
+	})
+
+	return &Portfolio{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   generatedAt,
+		Group:         group,
+		Teams:         sorted,
+	}
+}
+
+// Title — заголовок обзора: имя группы или «All teams».
+func (p *Portfolio) Title() string {
+	if p.Group != "" {
+		return p.Group
+	}
+	return "All teams"
+}
+
+// PrintPortfolio выводит портфельный обзор в консоль.
+func PrintPortfolio(p *Portfolio) {
+	boxTop(" 📊 Portfolio — " + p.Title())
+	boxLine(fmt.Sprintf("   %-16s %9s %4s %5s %7s %6s", "Team", "Done/Time", "WIP", "Aging", "Builds", "Risk"))
+	boxSeparator()
+
+	for _, t := range p.Teams {
+		if t.Error != "" {
+			boxLine(fmt.Sprintf("   %-16s %s", truncate(t.Team, 16), "⚠ no data"))
+			continue
+		}
+		boxLine(fmt.Sprintf("   %-16s %9s %4d %5d %7s %s %3d",
+			truncate(t.Team, 16), progressLabel(t), t.WIPViolations, t.AgingItems, buildsLabel(t),
+			riskLabel(t.Risk), t.RiskScore))
+	}
+
+	boxBottom()
+
+	for _, t := range p.Teams {
+		if t.Error != "" {
+			fmt.Printf("⚠ %s: %s\n", t.Team, t.Error)
+		}
+	}
+}
+
+func progressLabel(t analysis.PortfolioTeam) string {
+	return fmt.Sprintf("%.0f%%/%.0f%%", t.Progress*100, t.Elapsed*100)
+}
+
+func buildsLabel(t analysis.PortfolioTeam) string {
+	if t.BuildsTotal == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%d/%d red", t.BuildsRed, t.BuildsTotal)
+}
+
+func riskLabel(r analysis.RiskLevel) string {
+	switch r {
+	case analysis.RiskHigh:
+		return "🔴"
+	case analysis.RiskMedium:
+		return "🟠"
+	default:
+		return "🟢"
+	}
+}
+
+// WritePortfolioJSON пишет обзор одним JSON-объектом.
+func WritePortfolioJSON(w io.Writer, p *Portfolio) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WritePortfolioNDJSON пишет обзор построчно: запись meta и по записи team на команду.
+func WritePortfolioNDJSON(w io.Writer, p *Portfolio) error {
+	enc := json.NewEncoder(w)
+
+	if err := enc.Encode(ndjsonRecord{
+		SchemaVersion: p.SchemaVersion,
+		Type:          "meta",
+		Data:          ndjsonMeta{GeneratedAt: p.GeneratedAt},
+	}); err != nil {
+		return err
+	}
+	for _, t := range p.Teams {
+		if err := enc.Encode(ndjsonRecord{
+			SchemaVersion: p.SchemaVersion,
+			Type:          "team",
+			Team:          t.Team,
+			Data:          t,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WritePortfolioMarkdown пишет обзор таблицей Markdown.
+func WritePortfolioMarkdown(w io.Writer, p *Portfolio) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "## 📊 Portfolio — %s\n\n", mdEscape(p.Title()))
+	fmt.Fprintln(bw, "| Team | Sprint | Done | Time | Items | WIP violations | Aging | Builds | Success rate | On time | Risk |")
+	fmt.Fprintln(bw, "|------|--------|-----:|-----:|------:|---------------:|------:|-------:|-------------:|--------:|-----:|")
+	for _, t := range p.Teams {
+		if t.Error != "" {
+			fmt.Fprintf(bw, "| %s | ⚠ %s | | | | | | | | | %s %d |\n",
+				mdCell(t.Team), mdCell(t.Error), riskLabel(t.Risk), t.RiskScore)
+			continue
+		}
+		fmt.Fprintf(bw, "| %s | %s | %.0f%% | %.0f%% | %d/%d | %d | %d | %s | %s | %s | %s %d |\n",
+			mdCell(t.Team), mdCell(t.Sprint), t.Progress*100, t.Elapsed*100, t.ItemsDone, t.ItemsTotal,
+			t.WIPViolations, t.AgingItems, buildsLabel(t), successRateLabel(t), onTimeLabel(t),
+			riskLabel(t.Risk), t.RiskScore)
+	}
+	fmt.Fprintln(bw)
+
+	return bw.Flush()
+}
+
+func successRateLabel(t analysis.PortfolioTeam) string {
+	if t.BuildsTotal == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.0f%%", t.BuildSuccessRate*100)
+}
+
+func onTimeLabel(t analysis.PortfolioTeam) string {
+	if t.ForecastProbability == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%.0f%%", *t.ForecastProbability*100)
+}
+
+//go:embed templates/portfolio.html
+var portfolioTemplateSource string
+
+var portfolioTemplate = template.Must(template.New("portfolio").Funcs(template.FuncMap{
+	"percent":     func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) },
+	"builds":      buildsLabel,
+	"successRate": successRateLabel,
+	"onTime":      onTimeLabel,
+	"riskClass":   func(r analysis.RiskLevel) string { return "risk-" + strings.ToLower(string(r)) },
+	"dateTime":    func(t time.Time) string { return t.Local().Format("2006-01-02 15:04") },
+}).Parse(portfolioTemplateSource))
+
+// WritePortfolioHTML пишет самодостаточную HTML-страницу с обзором.
+func WritePortfolioHTML(w io.Writer, p *Portfolio) error {
+	return portfolioTemplate.Execute(w, p)
+}
@@ -0,0 +1,34 @@
+package report
+
+import (
+	"fmt"
+
+	"scrum-eye/internal/analysis"
+)
+
+// PrintScope выводит работу, добавленную в спринт и убранную из него после старта.
+func PrintScope(sc *analysis.ScopeChange) {
+	if sc == nil {
+		return
+	}
+
+	boxTop(fmt.Sprintf(" 🎯 Scope since %s", sc.CommittedAt.Local().Format("2006-01-02 15:04")))
+	boxLine(fmt.Sprintf("   Committed: %g SP (%d items)   Unplanned: %.0f%%",
+		sc.CommittedPoints, sc.CommittedItems, sc.UnplannedPercent))
+	boxLine(fmt.Sprintf("   Added: %g SP (%d)   Removed: %g SP (%d)",
+		sc.AddedPoints, len(sc.Added), sc.RemovedPoints, len(sc.Removed)))
+
+	if len(sc.Added) == 0 && len(sc.Removed) == 0 {
+		boxBottom()
+		return
+	}
+
+	boxSeparator()
+	for _, it := range sc.Added {
+		boxLine(fmt.Sprintf("   + %-6d %4s  %-14s %s", it.ID, formatPoints(it.StoryPoints), truncate(orNone(it.By), 14), it.Name))
+	}
+	for _, it := range sc.Removed {
+		boxLine(fmt.Sprintf("   - %-6d %4s  %-14s %s", it.ID, formatPoints(it.StoryPoints), "", it.Name))
+	}
+	boxBottom()
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// workItemTypeOrder — порядок типов в сводках отчётов.
+var workItemTypeOrder = []domain.WorkItemType{
+	domain.WorkItemStory,
+	domain.WorkItemBug,
+	domain.WorkItemTask,
+	domain.WorkItemEpic,
+	domain.WorkItemFeature,
+	domain.WorkItemImpediment,
+	domain.WorkItemUnknown,
+}
+
+// sprintDates возвращает даты начала и конца спринта и количество оставшихся дней.
+func sprintDates(sprint *domain.Sprint, now time.Time) (start, end, daysLeft string) {
+	start, end, daysLeft = "N/A", "N/A", "N/A"
+
+	if sprint.StartDate != nil {
+		start = sprint.StartDate.Format("2006-01-02")
+	}
+	if sprint.EndDate != nil {
+		end = sprint.EndDate.Format("2006-01-02")
+		daysLeft = fmt.Sprintf("%d", int(sprint.EndDate.Sub(now).Hours()/24))
+	}
+
+	return start, end, daysLeft
+}
+
+// workItemSummary формирует строку сводки по типам: "Total: 10, Story: 4, Bug: 6".
+func workItemSummary(items []domain.WorkItem) string {
+	typeCounts := map[domain.WorkItemType]int{}
+	for _, wi := range items {
+		typeCounts[wi.Type]++
+	}
+
+	summaryParts := make([]string, 0)
+	if len(items) > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("Total: %d", len(items)))
+	}
+	for _, t := range workItemTypeOrder {
+		if count := typeCounts[t]; count > 0 {
+			summaryParts = append(summaryParts, fmt.Sprintf("%s: %d", t, count))
+		}
+	}
+
+	if len(summaryParts) == 0 {
+		return "No work items"
+	}
+	return strings.Join(summaryParts, ", ")
+}
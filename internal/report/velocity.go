@@ -0,0 +1,86 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"scrum-eye/internal/analysis"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// PrintVelocity выводит таблицу скорости по спринтам и тренд.
+func PrintVelocity(v *analysis.Velocity) {
+	if v == nil || len(v.Sprints) == 0 {
+		return
+	}
+
+	boxTop(" 🚀 Velocity")
+	boxLine(fmt.Sprintf("   %-24s %9s %9s %7s", "Sprint", "Committed", "Done", "Say/Do"))
+	boxLine(fmt.Sprintf("   %-24s %9s %9s %7s", strings.Repeat("-", 24), "---------", "---------", "------"))
+
+	approximate := false
+	for _, s := range v.Sprints {
+		mark := ""
+		if approximateCommitment(s) {
+			mark, approximate = " *", true
+		}
+		boxLine(fmt.Sprintf("   %-24s %9s %9s %6.0f%%%s",
+			truncate(s.Name, 24),
+			fmt.Sprintf("%g (%d)", s.CommittedPoints, s.CommittedItems),
+			fmt.Sprintf("%g (%d)", s.CompletedPoints, s.CompletedItems),
+			s.SayDo*100, mark))
+	}
+	if approximate {
+		boxLine("   * commitment taken from sprint contents at the end")
+	}
+
+	boxSeparator()
+	boxLine(fmt.Sprintf("   Average: %.1f ± %.1f SP, %.1f ± %.1f items",
+		v.AveragePoints, v.StdDevPoints, v.AverageItems, v.StdDevItems))
+	boxLine(fmt.Sprintf("   Say/Do: %.0f%%   Trend: %s %s", v.AverageSayDo*100, sparkline(velocityCompleted(v)), trendLabel(v.Trend)))
+	boxBottom()
+}
+
+// approximateCommitment — обещанное спринта восстановлено по составу на конец, а не на начало.
+func approximateCommitment(s analysis.SprintVelocity) bool {
+	return s.CommitmentSource == analysis.CommitmentSprintEnd
+}
+
+// velocityCompleted — сделанные story points по спринтам, для спарклайна.
+func velocityCompleted(v *analysis.Velocity) []float64 {
+	completed := make([]float64, 0, len(v.Sprints))
+	for _, s := range v.Sprints {
+		completed = append(completed, s.CompletedPoints)
+	}
+	return completed
+}
+
+// sparkline рисует ряд значений блоками ▁…█.
+func sparkline(values []float64) string {
+	maxV := 0.0
+	for _, v := range values {
+		maxV = max(maxV, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if maxV > 0 {
+			idx = int(v / maxV * float64(len(sparkBlocks)-1))
+		}
+		sb.WriteRune(sparkBlocks[idx])
+	}
+	return sb.String()
+}
+
+func trendLabel(slope float64) string {
+	switch {
+	case slope > 0.5:
+		return fmt.Sprintf("▲ %+.1f SP/sprint", slope)
+	case slope < -0.5:
+		return fmt.Sprintf("▼ %+.1f SP/sprint", slope)
+	default:
+		return "▶ stable"
+	}
+}
@@ -0,0 +1,38 @@
+package report
+
+import (
+	"fmt"
+
+	"scrum-eye/internal/analysis"
+)
+
+// PrintWIP выводит незавершённую работу команды и каждого исполнителя рядом с лимитами.
+func PrintWIP(wip *analysis.WIPReport) {
+	if wip == nil || len(wip.People) == 0 {
+		return
+	}
+
+	boxTop(" 🚧 Work in Progress: " + wipTeamLabel(wip))
+	boxLine(fmt.Sprintf("   %-24s %11s %8s", "Assignee", "In progress", "Open SP"))
+	boxLine(fmt.Sprintf("   %-24s %11s %8s", "------------------------", "-----------", "--------"))
+	for _, p := range wip.People {
+		boxLine(fmt.Sprintf("   %-24s %11s %8g", truncate(p.Name, 24), wipPersonLabel(wip, p), p.OpenStoryPoints))
+	}
+	boxBottom()
+}
+
+// wipTeamLabel — элементы команды в работе и лимит, если он задан.
+func wipTeamLabel(wip *analysis.WIPReport) string {
+	if wip.WipLimit > 0 {
+		return fmt.Sprintf("%d of %d in progress", wip.TeamInProgress, wip.WipLimit)
+	}
+	return fmt.Sprintf("%d in progress", wip.TeamInProgress)
+}
+
+// wipPersonLabel — элементы исполнителя в работе; превышение личного лимита помечается.
+func wipPersonLabel(wip *analysis.WIPReport, p analysis.PersonLoad) string {
+	if wip.WipPerPerson > 0 && p.InProgress > wip.WipPerPerson && p.Name != analysis.Unassigned {
+		return fmt.Sprintf("%d ⚠", p.InProgress)
+	}
+	return fmt.Sprintf("%d", p.InProgress)
+}
@@ -8,12 +8,30 @@ import (
 	"net/http"
 	"net/url"
 	"scrum-eye/internal/config"
+	"scrum-eye/internal/sources"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
-const MaxWorkItems = 200
+// WorkItemsPageSize — размер одной страницы OData-запроса рабочих элементов.
+const WorkItemsPageSize = 200
+
+// DefaultMaxWorkItems — предел количества рабочих элементов итерации,
+// если в конфиге команды не задан azure.maxWorkItems.
+const DefaultMaxWorkItems = 5000
+
+const odataWorkItemFields = "WorkItemId,Title,WorkItemType,State,StateCategory,Priority,Severity," +
+	"OriginalEstimate,RemainingWork,CompletedWork,StoryPoints,Effort,Size,ParentWorkItemId,TagNames," +
+	"CreatedDate,ActivatedDate,ResolvedDate,ClosedDate,ChangedDate,StateChangeDate"
+
+const odataWorkItemExpand = "AssignedTo($select=UserName,UserEmail),Area($select=AreaPath)"
+
+var (
+	_ sources.BoardsClient = (*Client)(nil)
+	_ sources.ReposClient  = (*Client)(nil)
+)
 
 type Client struct {
 	organization string
@@ -21,6 +39,7 @@ type Client struct {
 	token        string
 	team         string
 	area         string
+	maxWorkItems int
 
 	baseRestUrl  string
 	baseOdataUrl string
@@ -31,12 +50,18 @@ func NewClient(azureCfg config.AzureDevOpsTeam) *Client {
 	baseRestUrl := "https://dev.azure.com/" + azureCfg.Organisation
 	baseOdataUrl := "https://analytics.dev.azure.com/" + azureCfg.Organisation
 
+	maxWorkItems := azureCfg.MaxWorkItems
+	if maxWorkItems <= 0 {
+		maxWorkItems = DefaultMaxWorkItems
+	}
+
 	return &Client{
 		organization: azureCfg.Organisation,
 		token:        azureCfg.Token,
 		team:         azureCfg.TeamId,
 		area:         azureCfg.AreaPath,
 		project:      azureCfg.ProjectId,
+		maxWorkItems: maxWorkItems,
 		baseRestUrl:  baseRestUrl,
 		baseOdataUrl: baseOdataUrl,
 		httpClient: &http.Client{
@@ -45,7 +70,36 @@ func NewClient(azureCfg config.AzureDevOpsTeam) *Client {
 	}
 }
 
-func (c *Client) GetCurrentIteration(ctx context.Context) (*Iteration, error) {
+// GetIterations возвращает все итерации команды (прошлые, текущую и будущие),
+// отсортированные по дате начала. Итерации без дат идут в конце.
+func (c *Client) GetIterations(ctx context.Context) ([]sources.IterationDTO, error) {
+	path := fmt.Sprintf("/%s/%s/_apis/work/teamsettings/iterations", c.project, c.team)
+
+	query := url.Values{}
+	query.Set("api-version", "7.1")
+
+	var resp iterationsListResponse
+	if err := c.doRestRequest(ctx, http.MethodGet, path, query, &resp); err != nil {
+		return nil, fmt.Errorf("getIterations: %w", err)
+	}
+
+	iterations := make([]sources.IterationDTO, 0, len(resp.Value))
+	for _, it := range resp.Value {
+		iterations = append(iterations, *mapIteration(it))
+	}
+
+	sort.SliceStable(iterations, func(i, j int) bool {
+		a, b := iterations[i].StartDate, iterations[j].StartDate
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+
+	return iterations, nil
+}
+
+func (c *Client) GetCurrentIteration(ctx context.Context) (*sources.IterationDTO, error) {
 	path := fmt.Sprintf("/%s/%s/_apis/work/teamsettings/iterations", c.project, c.team)
 
 	query := url.Values{}
@@ -62,27 +116,68 @@ func (c *Client) GetCurrentIteration(ctx context.Context) (*Iteration, error) {
 	}
 
 	// Azure DevOps обычно возвращает один current-iteration
-	return &resp.Value[0], nil
+	return mapIteration(resp.Value[0]), nil
 }
 
-func (c *Client) GetIterationWorkItems(iterationId string, ctx context.Context) (*[]ODataWorkItem, error) {
+// GetIterationWorkItems постранично выгружает рабочие элементы итерации.
+// Если сервер отдаёт @odata.nextLink, идём по нему, иначе листаем через $skip.
+// Выгрузка останавливается на maxWorkItems, при этом в результате выставляется Truncated.
+func (c *Client) GetIterationWorkItems(ctx context.Context, iterationId string) (*sources.WorkItemsResult, error) {
 	path := fmt.Sprintf("/%s/_odata/v4.0-preview/WorkItems", c.project)
-	query := url.Values{}
-	query.Set("$filter", fmt.Sprintf("IterationSK eq %s", iterationId))
-	query.Set("$select", "WorkItemId,Title,State")
-	query.Set("$orderBy", "WorkItemType desc")
-	query.Set("$top", strconv.Itoa(MaxWorkItems))
-
-	var resp ODataWorkItemsResponse
-	if err := c.doODataRequest(ctx, http.MethodGet, path, query, &resp); err != nil {
-		return nil, fmt.Errorf("getIterationWorkItems: %w", err)
+
+	items := make([]ODataWorkItem, 0, WorkItemsPageSize)
+	nextLink := ""
+
+	for {
+		// запрашиваем на один элемент больше предела, чтобы понять, что данные обрезаны
+		top := min(WorkItemsPageSize, c.maxWorkItems+1-len(items))
+
+		var resp ODataWorkItemsResponse
+		var err error
+		if nextLink != "" {
+			err = c.doRequestURL(ctx, http.MethodGet, nextLink, &resp)
+		} else {
+			query := url.Values{}
+			query.Set("$filter", fmt.Sprintf("IterationSK eq %s", iterationId))
+			query.Set("$select", odataWorkItemFields)
+			query.Set("$expand", odataWorkItemExpand)
+			query.Set("$orderby", "WorkItemType desc,WorkItemId")
+			query.Set("$top", strconv.Itoa(top))
+			query.Set("$skip", strconv.Itoa(len(items)))
+
+			err = c.doODataRequest(ctx, http.MethodGet, path, query, &resp)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getIterationWorkItems: %w", err)
+		}
+
+		items = append(items, resp.Value...)
+
+		switch {
+		case len(items) > c.maxWorkItems, len(resp.Value) == 0:
+		case resp.NextLink != "":
+			nextLink = resp.NextLink
+			continue
+		case nextLink == "" && len(resp.Value) >= top:
+			// сервер не отдаёт nextLink — листаем сами через $skip
+			continue
+		}
+		break
 	}
 
-	if len(resp.Value) == 0 {
-		return &[]ODataWorkItem{}, nil
+	result := &sources.WorkItemsResult{Limit: c.maxWorkItems}
+	if len(items) > c.maxWorkItems {
+		items = items[:c.maxWorkItems]
+		result.Truncated = true
 	}
+	result.Items = mapODataWorkItems(items, c.workItemWebBase())
+
+	return result, nil
+}
 
-	return &resp.Value, nil
+// workItemWebBase — адрес веб-формы рабочего элемента без ID.
+func (c *Client) workItemWebBase() string {
+	return fmt.Sprintf("%s/%s/_workitems/edit/", c.baseRestUrl, url.PathEscape(c.project))
 }
 
 func (c *Client) doRestRequest(ctx context.Context, method, path string, query url.Values, out any) error {
@@ -104,7 +199,12 @@ func (c *Client) doRequest(ctx context.Context, method, baseUrl, path string, qu
 		u.RawQuery = query.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	return c.doRequestURL(ctx, method, u.String(), out)
+}
+
+// doRequestURL выполняет запрос по готовому абсолютному адресу (например, @odata.nextLink).
+func (c *Client) doRequestURL(ctx context.Context, method, rawUrl string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, rawUrl, nil)
 	if err != nil {
 		return err
 	}
@@ -122,13 +222,13 @@ func (c *Client) doRequest(ctx context.Context, method, baseUrl, path string, qu
 	case http.StatusUnauthorized,
 		http.StatusForbidden,
 		http.StatusNonAuthoritativeInfo:
-		return fmt.Errorf("azure devops api returned %s for %s", resp.Status, u.String())
+		return fmt.Errorf("azure devops api returned %s for %s", resp.Status, rawUrl)
 	}
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		return fmt.Errorf("azure devops api returned %s for %s", resp.Status, u.String())
+		return fmt.Errorf("azure devops api returned %s for %s", resp.Status, rawUrl)
 	}
 
 	if out == nil {
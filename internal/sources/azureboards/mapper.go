@@ -0,0 +1,125 @@
+package azureboards
+
+import (
+	"scrum-eye/internal/sources"
+	"strconv"
+	"strings"
+)
+
+func mapIteration(src Iteration) *sources.IterationDTO {
+	return &sources.IterationDTO{
+		ID:         src.ID,
+		Name:       src.Name,
+		Path:       src.Path,
+		StartDate:  src.Attributes.StartDate,
+		FinishDate: src.Attributes.FinishDate,
+		TimeFrame:  src.Attributes.TimeFrame,
+	}
+}
+
+// mapODataWorkItems переводит элементы OData в DTO. webBase — адрес формы редактирования
+// элемента без ID, например https://dev.azure.com/org/project/_workitems/edit/.
+func mapODataWorkItems(src []ODataWorkItem, webBase string) []sources.WorkItemDTO {
+	dst := make([]sources.WorkItemDTO, 0, len(src))
+
+	for _, v := range src {
+		wi := sources.WorkItemDTO{
+			ID:               v.ID,
+			Title:            v.Title,
+			Type:             v.WorkItemType,
+			WebURL:           webBase + strconv.Itoa(v.ID),
+			State:            v.State,
+			StateCategory:    v.StateCategory,
+			Priority:         v.Priority,
+			Severity:         v.Severity,
+			StoryPoints:      float64(v.StoryPoints),
+			Effort:           float64(v.Effort),
+			OriginalEstimate: float64(v.OriginalEstimate),
+			RemainingWork:    float64(v.RemainingWork),
+			CompletedWork:    float64(v.CompletedWork),
+			ParentID:         v.ParentWorkItemId,
+			Tags:             splitTags(v.TagNames),
+			CreatedDate:      v.CreatedDate,
+			ActivatedDate:    v.ActivatedDate,
+			ResolvedDate:     v.ResolvedDate,
+			ClosedDate:       v.ClosedDate,
+			ChangedDate:      v.ChangedDate,
+			StateChangeDate:  v.StateChangeDate,
+		}
+
+		// в Basic-процессе оценка хранится в Effort, в CMMI — в Size
+		if wi.Effort == 0 {
+			wi.Effort = float64(v.Size)
+		}
+		if v.AssignedTo != nil {
+			wi.AssignedTo = v.AssignedTo.UserName
+			wi.AssignedToEmail = v.AssignedTo.UserEmail
+		}
+		if v.Area != nil {
+			wi.AreaPath = v.Area.AreaPath
+		}
+
+		dst = append(dst, wi)
+	}
+
+	return dst
+}
+
+func mapODataRevisions(src []ODataRevision) []sources.WorkItemRevisionDTO {
+	dst := make([]sources.WorkItemRevisionDTO, 0, len(src))
+
+	for _, v := range src {
+		rev := sources.WorkItemRevisionDTO{
+			WorkItemID:    v.WorkItemID,
+			Revision:      v.Revision,
+			State:         v.State,
+			StateCategory: v.StateCategory,
+			IterationID:   v.IterationSK,
+			ChangedDate:   v.ChangedDate,
+		}
+		if v.ChangedBy != nil {
+			rev.ChangedBy = v.ChangedBy.UserName
+		}
+
+		dst = append(dst, rev)
+	}
+
+	return dst
+}
+
+// splitTags разбирает строку TagNames вида "tag1; tag2".
+func splitTags(tagNames string) []string {
+	if strings.TrimSpace(tagNames) == "" {
+		return nil
+	}
+
+	parts := strings.Split(tagNames, ";")
+	tags := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if t := strings.TrimSpace(p); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+func mapPullRequests(src []PullRequest) []sources.PullRequestDTO {
+	dst := make([]sources.PullRequestDTO, 0, len(src))
+
+	for _, v := range src {
+		dst = append(dst, sources.PullRequestDTO{
+			ID:           v.ID,
+			Title:        v.Title,
+			Repository:   v.Repository.Name,
+			SourceBranch: v.SourceRefName,
+			TargetBranch: v.TargetRefName,
+			Status:       v.Status,
+			CreatedBy:    v.CreatedBy.DisplayName,
+			CreationDate: v.CreationDate,
+			ClosedDate:   v.ClosedDate,
+			IsDraft:      v.IsDraft,
+		})
+	}
+
+	return dst
+}
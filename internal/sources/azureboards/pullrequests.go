@@ -0,0 +1,29 @@
+package azureboards
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"scrum-eye/internal/sources"
+	"strconv"
+)
+
+const MaxPullRequests = 100
+
+// GetPullRequests возвращает последние пулл-реквесты репозитория в любом статусе.
+func (c *Client) GetPullRequests(ctx context.Context, repository string) ([]sources.PullRequestDTO, error) {
+	path := fmt.Sprintf("/%s/_apis/git/repositories/%s/pullrequests", c.project, url.PathEscape(repository))
+
+	query := url.Values{}
+	query.Set("api-version", "7.1")
+	query.Set("searchCriteria.status", "all")
+	query.Set("$top", strconv.Itoa(MaxPullRequests))
+
+	var resp pullRequestsListResponse
+	if err := c.doRestRequest(ctx, http.MethodGet, path, query, &resp); err != nil {
+		return nil, fmt.Errorf("getPullRequests: %w", err)
+	}
+
+	return mapPullRequests(resp.Value), nil
+}
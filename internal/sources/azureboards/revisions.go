@@ -0,0 +1,56 @@
+package azureboards
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"scrum-eye/internal/sources"
+	"strconv"
+	"strings"
+)
+
+// RevisionsBatchSize — сколько элементов запрашивать в одном фильтре WorkItemId in (...),
+// чтобы не упереться в длину URL.
+const RevisionsBatchSize = 100
+
+const odataRevisionFields = "WorkItemId,Revision,State,StateCategory,IterationSK,ChangedDate"
+
+// GetWorkItemRevisions выгружает ревизии элементов из Analytics (WorkItemRevisions).
+// Элементы запрашиваются пачками по RevisionsBatchSize, страницы — по @odata.nextLink.
+func (c *Client) GetWorkItemRevisions(ctx context.Context, ids []int) ([]sources.WorkItemRevisionDTO, error) {
+	path := fmt.Sprintf("/%s/_odata/v4.0-preview/WorkItemRevisions", c.project)
+
+	revisions := make([]ODataRevision, 0)
+	for start := 0; start < len(ids); start += RevisionsBatchSize {
+		batch := ids[start:min(start+RevisionsBatchSize, len(ids))]
+
+		list := make([]string, 0, len(batch))
+		for _, id := range batch {
+			list = append(list, strconv.Itoa(id))
+		}
+
+		query := url.Values{}
+		query.Set("$filter", fmt.Sprintf("WorkItemId in (%s)", strings.Join(list, ",")))
+		query.Set("$select", odataRevisionFields)
+		query.Set("$expand", "ChangedBy($select=UserName)")
+		query.Set("$orderby", "WorkItemId,Revision")
+
+		var resp ODataRevisionsResponse
+		if err := c.doODataRequest(ctx, http.MethodGet, path, query, &resp); err != nil {
+			return nil, fmt.Errorf("getWorkItemRevisions: %w", err)
+		}
+		revisions = append(revisions, resp.Value...)
+
+		for resp.NextLink != "" {
+			nextLink := resp.NextLink
+			resp = ODataRevisionsResponse{}
+			if err := c.doRequestURL(ctx, http.MethodGet, nextLink, &resp); err != nil {
+				return nil, fmt.Errorf("getWorkItemRevisions: %w", err)
+			}
+			revisions = append(revisions, resp.Value...)
+		}
+	}
+
+	return mapODataRevisions(revisions), nil
+}
@@ -49,18 +49,85 @@ type workItemsListResponse struct {
 }
 
 type ODataWorkItemsResponse struct {
-	Value []ODataWorkItem `json:"value"`
+	Value    []ODataWorkItem `json:"value"`
+	NextLink string          `json:"@odata.nextLink,omitempty"`
 }
 
 type ODataWorkItem struct {
-	ID               int     `json:"WorkItemId"`
-	Title            string  `json:"Title"`
-	WorkItemType     string  `json:"WorkItemType,omitempty"`
-	State            string  `json:"State,omitempty"`
-	Priority         int     `json:"Priority,omitempty"`
-	Severity         string  `json:"Severity,omitempty"`
-	OriginalEstimate float32 `json:"OriginalEstimate,omitempty"`
-	RemainingWork    float32 `json:"RemainingWork,omitempty"`
-	CompletedWork    float32 `json:"CompletedWork,omitempty"`
-	CommentsCount    int     `json:"CommentsCount,omitempty"`
+	ID               int        `json:"WorkItemId"`
+	Title            string     `json:"Title"`
+	WorkItemType     string     `json:"WorkItemType,omitempty"`
+	State            string     `json:"State,omitempty"`
+	StateCategory    string     `json:"StateCategory,omitempty"`
+	Priority         int        `json:"Priority,omitempty"`
+	Severity         string     `json:"Severity,omitempty"`
+	OriginalEstimate float32    `json:"OriginalEstimate,omitempty"`
+	RemainingWork    float32    `json:"RemainingWork,omitempty"`
+	CompletedWork    float32    `json:"CompletedWork,omitempty"`
+	StoryPoints      float32    `json:"StoryPoints,omitempty"`
+	Effort           float32    `json:"Effort,omitempty"`
+	Size             float32    `json:"Size,omitempty"`
+	CommentsCount    int        `json:"CommentsCount,omitempty"`
+	ParentWorkItemId int        `json:"ParentWorkItemId,omitempty"`
+	TagNames         string     `json:"TagNames,omitempty"`
+	CreatedDate      *time.Time `json:"CreatedDate,omitempty"`
+	ActivatedDate    *time.Time `json:"ActivatedDate,omitempty"`
+	ResolvedDate     *time.Time `json:"ResolvedDate,omitempty"`
+	ClosedDate       *time.Time `json:"ClosedDate,omitempty"`
+	ChangedDate      *time.Time `json:"ChangedDate,omitempty"`
+	StateChangeDate  *time.Time `json:"StateChangeDate,omitempty"`
+	AssignedTo       *ODataUser `json:"AssignedTo,omitempty"`
+	Area             *ODataArea `json:"Area,omitempty"`
+}
+
+type ODataRevisionsResponse struct {
+	Value    []ODataRevision `json:"value"`
+	NextLink string          `json:"@odata.nextLink,omitempty"`
+}
+
+type ODataRevision struct {
+	WorkItemID    int        `json:"WorkItemId"`
+	Revision      int        `json:"Revision"`
+	State         string     `json:"State,omitempty"`
+	StateCategory string     `json:"StateCategory,omitempty"`
+	IterationSK   string     `json:"IterationSK,omitempty"`
+	ChangedDate   *time.Time `json:"ChangedDate,omitempty"`
+	ChangedBy     *ODataUser `json:"ChangedBy,omitempty"`
+}
+
+type ODataUser struct {
+	UserName  string `json:"UserName"`
+	UserEmail string `json:"UserEmail,omitempty"`
+}
+
+type ODataArea struct {
+	AreaPath string `json:"AreaPath"`
+}
+
+type pullRequestsListResponse struct {
+	Value []PullRequest `json:"value"`
+	Count int           `json:"count"`
+}
+
+type PullRequest struct {
+	ID            int           `json:"pullRequestId"`
+	Title         string        `json:"title"`
+	Status        string        `json:"status"`
+	SourceRefName string        `json:"sourceRefName"`
+	TargetRefName string        `json:"targetRefName"`
+	IsDraft       bool          `json:"isDraft"`
+	CreationDate  *time.Time    `json:"creationDate,omitempty"`
+	ClosedDate    *time.Time    `json:"closedDate,omitempty"`
+	CreatedBy     IdentityRef   `json:"createdBy"`
+	Repository    RepositoryRef `json:"repository"`
+}
+
+type IdentityRef struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+type RepositoryRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
@@ -0,0 +1,135 @@
+package teamcity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"scrum-eye/internal/config"
+	"scrum-eye/internal/sources"
+	"strings"
+	"time"
+)
+
+// DefaultMaxBuilds — сколько сборок запрашивать, если в запросе не указан Count.
+const DefaultMaxBuilds = 20
+
+const buildFields = "build(id,number,status,state,statusText,branchName,webUrl," +
+	"queuedDate,startDate,finishDate,buildType(id,name)," +
+	"triggered(type,user(username,name)),changes(count)," +
+	"testOccurrences(count,passed,failed,ignored,muted))"
+
+var _ sources.CIClient = (*Client)(nil)
+
+type Client struct {
+	baseUrl    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(tcCfg config.TeamCityTeam) *Client {
+	return &Client{
+		baseUrl: strings.TrimRight(tcCfg.BaseURL, "/"),
+		token:   tcCfg.Token,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// GetBuilds возвращает последние завершённые сборки конфигурации, от новых к старым.
+func (c *Client) GetBuilds(ctx context.Context, q sources.BuildsQuery) ([]sources.BuildDTO, error) {
+	count := q.Count
+	if count <= 0 {
+		count = DefaultMaxBuilds
+	}
+
+	branch := "branch:(default:true)"
+	if q.Branch != "" {
+		branch = fmt.Sprintf("branch:(name:%s)", locatorValue(q.Branch))
+	}
+
+	query := url.Values{}
+	query.Set("locator", fmt.Sprintf("buildType:(id:%s),%s,state:finished,count:%d",
+		locatorValue(q.BuildConfigID), branch, count))
+	query.Set("fields", buildFields)
+
+	var resp buildsResponse
+	if err := c.doRequest(ctx, http.MethodGet, "/app/rest/builds", query, &resp); err != nil {
+		return nil, fmt.Errorf("getBuilds %s: %w", q.BuildConfigID, err)
+	}
+	builds := resp.Build
+
+	// сервер может урезать страницу (rest.defaultPageSize): догружаем по nextHref
+	for len(builds) < count && resp.NextHref != "" {
+		next, err := url.Parse(resp.NextHref)
+		if err != nil {
+			return nil, fmt.Errorf("getBuilds %s: invalid nextHref %q: %w", q.BuildConfigID, resp.NextHref, err)
+		}
+
+		resp = buildsResponse{}
+		if err := c.doRequest(ctx, http.MethodGet, next.Path, next.Query(), &resp); err != nil {
+			return nil, fmt.Errorf("getBuilds %s: %w", q.BuildConfigID, err)
+		}
+		builds = append(builds, resp.Build...)
+	}
+	if len(builds) > count {
+		builds = builds[:count]
+	}
+
+	return mapBuilds(builds), nil
+}
+
+// locatorValue экранирует значение измерения локатора. Запятые, двоеточия и скобки
+// ломают разбор локатора, поэтому такие значения передаются в виде $base64:.
+func locatorValue(v string) string {
+	if !strings.ContainsAny(v, ",:()$") {
+		return v
+	}
+	return "($base64:" + base64.RawURLEncoding.EncodeToString([]byte(v)) + ")"
+}
+
+// doRequest выполняет запрос к REST API. path считается от адреса сервера; nextHref
+// TeamCity уже содержит путь сервера, если он развёрнут не в корне, — он не дублируется.
+func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values, out any) error {
+	u, err := url.Parse(c.baseUrl)
+	if err != nil {
+		return err
+	}
+	base := strings.TrimRight(u.Path, "/")
+	if base != "" && strings.HasPrefix(path, base+"/") {
+		u.Path = path
+	} else {
+		u.Path = base + path
+	}
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("teamcity api returned %s for %s", resp.Status, u.String())
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
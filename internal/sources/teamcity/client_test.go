@@ -0,0 +1,155 @@
+package teamcity
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"scrum-eye/internal/config"
+	"scrum-eye/internal/sources"
+)
+
+func TestGetBuildsSendsTokenAndLocator(t *testing.T) {
+	var auth, locator string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/rest/builds" {
+			t.Errorf("path = %q, want /app/rest/builds", r.URL.Path)
+		}
+		auth = r.Header.Get("Authorization")
+		locator = r.URL.Query().Get("locator")
+		fmt.Fprint(w, `{"count":0,"build":[]}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(config.TeamCityTeam{BaseURL: srv.URL + "/", Token: "secret"})
+	if _, err := c.GetBuilds(context.Background(), sources.BuildsQuery{BuildConfigID: "Web_Build", Branch: "feature/a,b(1)", Count: 5}); err != nil {
+		t.Fatalf("GetBuilds: %v", err)
+	}
+
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", auth, "Bearer secret")
+	}
+	encoded := base64.RawURLEncoding.EncodeToString([]byte("feature/a,b(1)"))
+	want := "buildType:(id:Web_Build),branch:(name:($base64:" + encoded + ")),state:finished,count:5"
+	if locator != want {
+		t.Errorf("locator = %q, want %q", locator, want)
+	}
+}
+
+func TestGetBuildsDefaultBranchWithoutToken(t *testing.T) {
+	var auth, locator string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		locator = r.URL.Query().Get("locator")
+		fmt.Fprint(w, `{"count":0}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(config.TeamCityTeam{BaseURL: srv.URL})
+	if _, err := c.GetBuilds(context.Background(), sources.BuildsQuery{BuildConfigID: "Web_Build"}); err != nil {
+		t.Fatalf("GetBuilds: %v", err)
+	}
+
+	if auth != "" {
+		t.Errorf("Authorization = %q, want empty", auth)
+	}
+	want := fmt.Sprintf("buildType:(id:Web_Build),branch:(default:true),state:finished,count:%d", DefaultMaxBuilds)
+	if locator != want {
+		t.Errorf("locator = %q, want %q", locator, want)
+	}
+}
+
+func TestGetBuildsFollowsNextHref(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !strings.HasPrefix(r.URL.Path, "/tc/app/rest/builds") {
+			t.Errorf("path = %q, want /tc/app/rest/builds", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("page %d: token is missing", requests)
+		}
+
+		switch r.URL.Query().Get("start") {
+		case "":
+			fmt.Fprint(w, `{"count":2,"build":[{"id":3},{"id":2}],"nextHref":"/tc/app/rest/builds?locator=count:2&start=2"}`)
+		case "2":
+			fmt.Fprint(w, `{"count":2,"build":[{"id":1},{"id":0}],"nextHref":"/tc/app/rest/builds?locator=count:2&start=4"}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.RawQuery)
+			fmt.Fprint(w, `{"count":0}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(config.TeamCityTeam{BaseURL: srv.URL + "/tc", Token: "secret"})
+	builds, err := c.GetBuilds(context.Background(), sources.BuildsQuery{BuildConfigID: "Web_Build", Count: 3})
+	if err != nil {
+		t.Fatalf("GetBuilds: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	var ids []string
+	for _, b := range builds {
+		ids = append(ids, b.ID)
+	}
+	if got := strings.Join(ids, ","); got != "3,2,1" {
+		t.Errorf("build ids = %s, want 3,2,1", got)
+	}
+}
+
+func TestGetBuildsParsesTimes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count":1,"build":[{"id":7,"status":"SUCCESS",
+			"queuedDate":"20240115T103000+0300","startDate":"20240115T103100+0300","finishDate":""}]}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(config.TeamCityTeam{BaseURL: srv.URL})
+	builds, err := c.GetBuilds(context.Background(), sources.BuildsQuery{BuildConfigID: "Web_Build"})
+	if err != nil {
+		t.Fatalf("GetBuilds: %v", err)
+	}
+	if len(builds) != 1 {
+		t.Fatalf("builds = %d, want 1", len(builds))
+	}
+
+	b := builds[0]
+	want := time.Date(2024, 1, 15, 7, 30, 0, 0, time.UTC)
+	if b.QueuedDate == nil || !b.QueuedDate.Equal(want) {
+		t.Errorf("QueuedDate = %v, want %v", b.QueuedDate, want)
+	}
+	if b.StartDate == nil || !b.StartDate.Equal(want.Add(time.Minute)) {
+		t.Errorf("StartDate = %v, want %v", b.StartDate, want.Add(time.Minute))
+	}
+	if b.FinishDate != nil {
+		t.Errorf("FinishDate = %v, want nil", b.FinishDate)
+	}
+}
+
+func TestTimeRejectsUnknownLayout(t *testing.T) {
+	var v Time
+	if err := v.UnmarshalJSON([]byte(`"2024-01-15T10:30:00Z"`)); err == nil {
+		t.Errorf("UnmarshalJSON accepted RFC 3339, want an error")
+	}
+}
+
+func TestGetBuildsReportsHTTPStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no access", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := NewClient(config.TeamCityTeam{BaseURL: srv.URL, Token: "wrong"})
+	_, err := c.GetBuilds(context.Background(), sources.BuildsQuery{BuildConfigID: "Web_Build"})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("err = %v, want a 401 error", err)
+	}
+}
@@ -0,0 +1,60 @@
+package teamcity
+
+import (
+	"scrum-eye/internal/sources"
+	"strconv"
+	"time"
+)
+
+func mapBuilds(src []Build) []sources.BuildDTO {
+	dst := make([]sources.BuildDTO, 0, len(src))
+
+	for _, v := range src {
+		b := sources.BuildDTO{
+			ID:              strconv.Itoa(v.ID),
+			Number:          v.Number,
+			BuildConfigID:   v.BuildType.ID,
+			BuildConfigName: v.BuildType.Name,
+			Branch:          v.BranchName,
+			Status:          v.Status,
+			StatusText:      v.StatusText,
+			State:           v.State,
+			WebURL:          v.WebURL,
+			QueuedDate:      timePtr(v.QueuedDate),
+			StartDate:       timePtr(v.StartDate),
+			FinishDate:      timePtr(v.FinishDate),
+		}
+
+		if v.Triggered != nil {
+			b.TriggeredBy = v.Triggered.Type
+			if v.Triggered.User != nil {
+				b.TriggeredBy = v.Triggered.User.Name
+				if b.TriggeredBy == "" {
+					b.TriggeredBy = v.Triggered.User.Username
+				}
+			}
+		}
+		if v.Changes != nil {
+			b.ChangesCount = v.Changes.Count
+		}
+		if t := v.TestOccurrences; t != nil {
+			b.TestsTotal = t.Count
+			b.TestsPassed = t.Passed
+			b.TestsFailed = t.Failed
+			b.TestsIgnored = t.Ignored
+			b.TestsMuted = t.Muted
+		}
+
+		dst = append(dst, b)
+	}
+
+	return dst
+}
+
+func timePtr(t *Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
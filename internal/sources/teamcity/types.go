@@ -0,0 +1,79 @@
+package teamcity
+
+import (
+	"strings"
+	"time"
+)
+
+// timeLayout — формат дат TeamCity REST API, например 20240115T103000+0300.
+const timeLayout = "20060102T150405-0700"
+
+// Time разбирает даты в формате TeamCity.
+type Time struct {
+	time.Time
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+
+	parsed, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+type buildsResponse struct {
+	Count int     `json:"count"`
+	Build []Build `json:"build"`
+	// NextHref — ссылка на следующую страницу, если сервер вернул не все сборки.
+	NextHref string `json:"nextHref"`
+}
+
+type Build struct {
+	ID              int              `json:"id"`
+	Number          string           `json:"number"`
+	Status          string           `json:"status"`
+	State           string           `json:"state"`
+	StatusText      string           `json:"statusText"`
+	BranchName      string           `json:"branchName"`
+	WebURL          string           `json:"webUrl"`
+	QueuedDate      *Time            `json:"queuedDate,omitempty"`
+	StartDate       *Time            `json:"startDate,omitempty"`
+	FinishDate      *Time            `json:"finishDate,omitempty"`
+	BuildType       BuildType        `json:"buildType"`
+	Triggered       *Triggered       `json:"triggered,omitempty"`
+	Changes         *Changes         `json:"changes,omitempty"`
+	TestOccurrences *TestOccurrences `json:"testOccurrences,omitempty"`
+}
+
+type BuildType struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Triggered struct {
+	Type string `json:"type"`
+	User *User  `json:"user,omitempty"`
+}
+
+type User struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+type Changes struct {
+	Count int `json:"count"`
+}
+
+type TestOccurrences struct {
+	Count   int `json:"count"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Ignored int `json:"ignored"`
+	Muted   int `json:"muted"`
+}
@@ -1,16 +1,136 @@
 package sources
 
+import (
+	"context"
+	"time"
+)
+
+// Значения IterationDTO.TimeFrame.
+const (
+	TimeFramePast    = "past"
+	TimeFrameCurrent = "current"
+	TimeFrameFuture  = "future"
+)
+
+// IterationDTO — итерация (спринт) в терминах трекера задач.
+type IterationDTO struct {
+	ID         string
+	Name       string
+	Path       string
+	StartDate  *time.Time
+	FinishDate *time.Time
+	TimeFrame  string
+}
+
+// WorkItemDTO — рабочий элемент в том виде, в котором его отдаёт источник.
+// Нормализация типов и состояний выполняется в collector.
 type WorkItemDTO struct {
+	ID               int
+	Title            string
+	Type             string
+	WebURL           string
+	State            string
+	StateCategory    string
+	AssignedTo       string
+	AssignedToEmail  string
+	Priority         int
+	Severity         string
+	StoryPoints      float64
+	Effort           float64
+	OriginalEstimate float64
+	RemainingWork    float64
+	CompletedWork    float64
+	ParentID         int
+	Tags             []string
+	AreaPath         string
+	CreatedDate      *time.Time
+	ActivatedDate    *time.Time
+	ResolvedDate     *time.Time
+	ClosedDate       *time.Time
+	ChangedDate      *time.Time
+	StateChangeDate  *time.Time
+}
+
+// WorkItemsResult — выгрузка рабочих элементов итерации.
+// Truncated выставляется, если источник упёрся в предел Limit и часть элементов не получена.
+type WorkItemsResult struct {
+	Items     []WorkItemDTO
+	Truncated bool
+	Limit     int
 }
 
+// WorkItemRevisionDTO — одна ревизия рабочего элемента: его состояние после изменения.
+type WorkItemRevisionDTO struct {
+	WorkItemID    int
+	Revision      int
+	State         string
+	StateCategory string
+	IterationID   string
+	ChangedBy     string
+	ChangedDate   *time.Time
+}
+
+// BuildDTO — сборка CI-сервера.
 type BuildDTO struct {
+	ID              string
+	Number          string
+	BuildConfigID   string
+	BuildConfigName string
+	Branch          string
+	Status          string
+	StatusText      string
+	State           string
+	WebURL          string
+	QueuedDate      *time.Time
+	StartDate       *time.Time
+	FinishDate      *time.Time
+	TriggeredBy     string
+	ChangesCount    int
+	TestsTotal      int
+	TestsPassed     int
+	TestsFailed     int
+	TestsIgnored    int
+	TestsMuted      int
+}
+
+// BuildsQuery — параметры выборки сборок.
+// Пустой Branch означает ветку по умолчанию в CI.
+type BuildsQuery struct {
+	BuildConfigID string
+	Branch        string
+	Count         int
+}
+
+// PullRequestDTO — пулл-реквест в репозитории.
+type PullRequestDTO struct {
+	ID           int
+	Title        string
+	Repository   string
+	SourceBranch string
+	TargetBranch string
+	Status       string
+	CreatedBy    string
+	CreationDate *time.Time
+	ClosedDate   *time.Time
+	IsDraft      bool
 }
 
+// BoardsClient — источник итераций и рабочих элементов (Azure Boards, Jira и т.п.).
 type BoardsClient interface {
+	GetCurrentIteration(ctx context.Context) (*IterationDTO, error)
+	// GetIterations возвращает все итерации команды, отсортированные по дате начала.
+	GetIterations(ctx context.Context) ([]IterationDTO, error)
+	GetIterationWorkItems(ctx context.Context, iterationID string) (*WorkItemsResult, error)
+	// GetWorkItemRevisions возвращает ревизии указанных элементов, упорядоченные по элементу и номеру ревизии.
+	GetWorkItemRevisions(ctx context.Context, ids []int) ([]WorkItemRevisionDTO, error)
 }
 
+// ReposClient — источник пулл-реквестов.
 type ReposClient interface {
+	GetPullRequests(ctx context.Context, repository string) ([]PullRequestDTO, error)
 }
 
+// CIClient — источник сборок.
 type CIClient interface {
+	GetBuilds(ctx context.Context, query BuildsQuery) ([]BuildDTO, error)
 }
@@ -1 +1,336 @@
 package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+// SnapshotVersion — текущая версия формата снимка.
+// Увеличивается при несовместимых изменениях domain.Project.
+const SnapshotVersion = 1
+
+const (
+	commitmentPrefix   = "commitment-"
+	snapshotPrefix     = "snapshot-"
+	snapshotExt        = ".json"
+	snapshotTimeLayout = "20060102T150405Z"
+)
+
+// ErrNotFound возвращается, когда подходящего снимка нет.
+var ErrNotFound = errors.New("snapshot not found")
+
+// Snapshot — сохранённое состояние проекта на момент запуска.
+type Snapshot struct {
+	Version int             `json:"version"`
+	Team    string          `json:"team"`
+	TakenAt time.Time       `json:"takenAt"`
+	Project *domain.Project `json:"project"`
+}
+
+// SnapshotInfo описывает снимок на диске без чтения его содержимого.
+type SnapshotInfo struct {
+	Team    string
+	TakenAt time.Time
+	Path    string
+}
+
+// RetentionPolicy задаёт, какие снимки удалять при очистке.
+// Нулевые значения означают «без ограничения». Самый свежий снимок не удаляется никогда.
+type RetentionPolicy struct {
+	MaxAge   time.Duration
+	MaxCount int
+}
+
+// FileSystem хранит снимки в виде JSON-файлов в <root>/<team>/.
+type FileSystem struct {
+	root string
+}
+
+func NewFileSystem(root string) *FileSystem {
+	return &FileSystem{root: root}
+}
+
+func (fs *FileSystem) teamDir(team string) string {
+	return filepath.Join(fs.root, safeName(team))
+}
+
+// pathReplacer убирает из имени разделители путей и переход в родительскую директорию.
+var pathReplacer = strings.NewReplacer("/", "_", `\`, "_", "..", "_")
+
+// safeName превращает имя команды или ID спринта в имя файла, которое
+// не выходит за пределы своей директории.
+func safeName(name string) string {
+	name = pathReplacer.Replace(name)
+	if name == "" || name == "." {
+		return "_"
+	}
+	return name
+}
+
+// Save сохраняет проект как снимок команды на момент takenAt.
+func (fs *FileSystem) Save(team string, project *domain.Project, takenAt time.Time) (SnapshotInfo, error) {
+	takenAt = takenAt.UTC().Truncate(time.Second)
+	path := filepath.Join(fs.teamDir(team), snapshotPrefix+takenAt.Format(snapshotTimeLayout)+snapshotExt)
+
+	if err := writeSnapshot(path, team, project, takenAt); err != nil {
+		return SnapshotInfo{}, err
+	}
+	return SnapshotInfo{Team: team, TakenAt: takenAt, Path: path}, nil
+}
+
+// writeSnapshot записывает снимок в path. Пишем во временный файл и переименовываем,
+// чтобы при сбое не оставить обрезанный снимок.
+func writeSnapshot(path, team string, project *domain.Project, takenAt time.Time) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("не удалось создать директорию %s: %w", dir, err)
+	}
+
+	snapshot := Snapshot{
+		Version: SnapshotVersion,
+		Team:    team,
+		TakenAt: takenAt.UTC().Truncate(time.Second),
+		Project: project,
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать снимок: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось записать снимок %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("не удалось сохранить снимок %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// List возвращает снимки команды, отсортированные от старых к новым.
+func (fs *FileSystem) List(team string) ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(fs.teamDir(team))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]SnapshotInfo, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || !strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotExt)
+		takenAt, err := time.Parse(snapshotTimeLayout, stamp)
+		if err != nil {
+			// чужой файл с похожим именем — пропускаем
+			continue
+		}
+
+		infos = append(infos, SnapshotInfo{
+			Team:    team,
+			TakenAt: takenAt,
+			Path:    filepath.Join(fs.teamDir(team), name),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].TakenAt.Before(infos[j].TakenAt)
+	})
+
+	return infos, nil
+}
+
+// Load читает снимок с диска и проверяет версию формата.
+func (fs *FileSystem) Load(info SnapshotInfo) (*Snapshot, error) {
+	data, err := os.ReadFile(info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("снимок %s повреждён: %w", info.Path, err)
+	}
+	if snapshot.Version > SnapshotVersion {
+		return nil, fmt.Errorf("снимок %s имеет версию %d, поддерживается до %d",
+			info.Path, snapshot.Version, SnapshotVersion)
+	}
+
+	return &snapshot, nil
+}
+
+// LoadByDate возвращает последний снимок, сделанный в тот же календарный день, что и date
+// (день определяется в часовом поясе date).
+func (fs *FileSystem) LoadByDate(team string, date time.Time) (*Snapshot, error) {
+	infos, err := fs.List(team)
+	if err != nil {
+		return nil, err
+	}
+
+	y, m, d := date.Date()
+	for i := len(infos) - 1; i >= 0; i-- {
+		iy, im, id := infos[i].TakenAt.In(date.Location()).Date()
+		if iy == y && im == m && id == d {
+			return fs.Load(infos[i])
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// LatestBefore возвращает самый свежий снимок, сделанный не позже t.
+func (fs *FileSystem) LatestBefore(team string, t time.Time) (*Snapshot, error) {
+	infos, err := fs.List(team)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(infos) - 1; i >= 0; i-- {
+		if !infos[i].TakenAt.After(t) {
+			return fs.Load(infos[i])
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// Prune удаляет снимки, не подходящие под политику хранения, и возвращает их количество.
+func (fs *FileSystem) Prune(team string, policy RetentionPolicy, now time.Time) (int, error) {
+	infos, err := fs.List(team)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for i, info := range infos {
+		// последний снимок оставляем всегда
+		if i == len(infos)-1 {
+			break
+		}
+
+		tooOld := policy.MaxAge > 0 && now.Sub(info.TakenAt) > policy.MaxAge
+		tooMany := policy.MaxCount > 0 && len(infos)-i > policy.MaxCount
+		if !tooOld && !tooMany {
+			continue
+		}
+
+		if err := os.Remove(info.Path); err != nil {
+			return removed, fmt.Errorf("не удалось удалить снимок %s: %w", info.Path, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// SaveCommitment сохраняет снимок обязательств спринта — состав спринта на момент его начала.
+// Хранится отдельно от ежедневных снимков и не удаляется при очистке.
+func (fs *FileSystem) SaveCommitment(team, sprintID string, project *domain.Project, takenAt time.Time) error {
+	return writeSnapshot(fs.commitmentPath(team, sprintID), team, project, takenAt)
+}
+
+// LoadCommitment читает снимок обязательств спринта или возвращает ErrNotFound.
+func (fs *FileSystem) LoadCommitment(team, sprintID string) (*Snapshot, error) {
+	path := fs.commitmentPath(team, sprintID)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return fs.Load(SnapshotInfo{Team: team, Path: path})
+}
+
+func (fs *FileSystem) commitmentPath(team, sprintID string) string {
+	// ID итерации — GUID, но на всякий случай не даём ему выйти за пределы директории
+	return filepath.Join(fs.teamDir(team), commitmentPrefix+safeName(sprintID)+snapshotExt)
+}
+
+// ReportPath возвращает путь для архивного отчёта команды за день:
+// <root>/<team>/reports/<YYYY-MM-DD>.<ext>.
+func (fs *FileSystem) ReportPath(team string, day time.Time, ext string) string {
+	return filepath.Join(fs.ReportsDir(team), day.Format("2006-01-02")+"."+ext)
+}
+
+// ReportsDir — директория архивных отчётов команды.
+func (fs *FileSystem) ReportsDir(team string) string {
+	return filepath.Join(fs.teamDir(team), "reports")
+}
+
+// Reports возвращает имена архивных отчётов команды с расширением ext, от новых к старым.
+func (fs *FileSystem) Reports(team, ext string) ([]string, error) {
+	entries, err := os.ReadDir(fs.ReportsDir(team))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), "."+ext) {
+			names = append(names, e.Name())
+		}
+	}
+	// имена начинаются с даты, поэтому обратная сортировка даёт новые сверху
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	return names, nil
+}
+
+// Teams возвращает команды, для которых в хранилище есть данные.
+func (fs *FileSystem) Teams() ([]string, error) {
+	entries, err := os.ReadDir(fs.root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			teams = append(teams, e.Name())
+		}
+	}
+	return teams, nil
+}
+
+// LoadRange загружает снимки команды, сделанные в интервале [from, to], от старых к новым.
+func (fs *FileSystem) LoadRange(team string, from, to time.Time) ([]*Snapshot, error) {
+	infos, err := fs.List(team)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Snapshot, 0)
+	for _, info := range infos {
+		if info.TakenAt.Before(from) || info.TakenAt.After(to) {
+			continue
+		}
+
+		snapshot, err := fs.Load(info)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, snapshot)
+	}
+
+	return result, nil
+}
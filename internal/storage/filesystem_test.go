@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"scrum-eye/internal/domain"
+)
+
+var base = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+func project(name string) *domain.Project {
+	return &domain.Project{CurrentSprint: &domain.Sprint{ID: "s1", Name: name}}
+}
+
+// saveDays сохраняет по снимку в день начиная с base; имя спринта — номер дня.
+func saveDays(t *testing.T, fs *FileSystem, team string, n int) []SnapshotInfo {
+	t.Helper()
+	infos := make([]SnapshotInfo, 0, n)
+	for i := 0; i < n; i++ {
+		info, err := fs.Save(team, project(string(rune('a'+i))), base.AddDate(0, 0, i))
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+
+	info, err := fs.Save("team", project("Sprint 1"), base.Add(500*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if !info.TakenAt.Equal(base) {
+		t.Errorf("TakenAt = %v, want %v truncated to seconds", info.TakenAt, base)
+	}
+
+	snapshot, err := fs.Load(info)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if snapshot.Version != SnapshotVersion || snapshot.Team != "team" || snapshot.Project.CurrentSprint.Name != "Sprint 1" {
+		t.Errorf("snapshot = %+v, want team's Sprint 1 of the current version", snapshot)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(info.Path))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temporary file %s left behind", e.Name())
+		}
+	}
+}
+
+func TestListSkipsForeignFiles(t *testing.T) {
+	root := t.TempDir()
+	fs := NewFileSystem(root)
+	saveDays(t, fs, "team", 2)
+
+	for _, name := range []string{"snapshot-garbage.json", "notes.json", "snapshot-20240301T000000Z.json.tmp"} {
+		if err := os.WriteFile(filepath.Join(root, "team", name), []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	infos, err := fs.List("team")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(infos) != 2 || !infos[0].TakenAt.Before(infos[1].TakenAt) {
+		t.Errorf("List = %+v, want two snapshots from oldest to newest", infos)
+	}
+
+	if infos, err := fs.List("nobody"); err != nil || len(infos) != 0 {
+		t.Errorf("List(nobody) = %v, %v, want no snapshots and no error", infos, err)
+	}
+}
+
+func TestLoadRejectsNewerVersion(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+	info, err := fs.Save("team", project("Sprint 1"), base)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data := []byte(`{"version": 99, "team": "team", "takenAt": "2024-03-04T09:00:00Z", "project": null}`)
+	if err := os.WriteFile(info.Path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = fs.Load(info)
+	if err == nil || !strings.Contains(err.Error(), "версию 99") {
+		t.Errorf("Load = %v, want an unsupported version error", err)
+	}
+}
+
+func TestLatestBefore(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+	saveDays(t, fs, "team", 3)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"exactly at a snapshot", base.AddDate(0, 0, 1), "b"},
+		{"a second before the next one", base.AddDate(0, 0, 2).Add(-time.Second), "b"},
+		{"after the last one", base.AddDate(0, 1, 0), "c"},
+		{"before the first one", base.Add(-time.Second), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := fs.LatestBefore("team", tt.at)
+			if tt.want == "" {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("LatestBefore = %v, want ErrNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LatestBefore: %v", err)
+			}
+			if got := snapshot.Project.CurrentSprint.Name; got != tt.want {
+				t.Errorf("LatestBefore = snapshot %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := base.AddDate(0, 0, 4)
+
+	tests := []struct {
+		name    string
+		policy  RetentionPolicy
+		removed int
+		left    string
+	}{
+		{"no limits", RetentionPolicy{}, 0, "abcde"},
+		{"max count", RetentionPolicy{MaxCount: 2}, 3, "de"},
+		{"max count above total", RetentionPolicy{MaxCount: 10}, 0, "abcde"},
+		{"max age", RetentionPolicy{MaxAge: 48 * time.Hour}, 2, "cde"},
+		{"both limits", RetentionPolicy{MaxAge: 72 * time.Hour, MaxCount: 3}, 2, "cde"},
+		{"newest is always kept", RetentionPolicy{MaxAge: time.Hour}, 4, "e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := NewFileSystem(t.TempDir())
+			saveDays(t, fs, "team", 5)
+
+			removed, err := fs.Prune("team", tt.policy, now)
+			if err != nil {
+				t.Fatalf("Prune: %v", err)
+			}
+			if removed != tt.removed {
+				t.Errorf("removed = %d, want %d", removed, tt.removed)
+			}
+
+			infos, err := fs.List("team")
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			left := ""
+			for _, info := range infos {
+				snapshot, err := fs.Load(info)
+				if err != nil {
+					t.Fatalf("Load: %v", err)
+				}
+				left += snapshot.Project.CurrentSprint.Name
+			}
+			if left != tt.left {
+				t.Errorf("left = %q, want %q", left, tt.left)
+			}
+		})
+	}
+}
+
+func TestCommitmentSurvivesPrune(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+
+	if _, err := fs.LoadCommitment("team", "s1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LoadCommitment before save = %v, want ErrNotFound", err)
+	}
+
+	if err := fs.SaveCommitment("team", "s1", project("planned"), base); err != nil {
+		t.Fatalf("SaveCommitment: %v", err)
+	}
+	saveDays(t, fs, "team", 3)
+	if _, err := fs.Prune("team", RetentionPolicy{MaxCount: 1}, base.AddDate(0, 0, 3)); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	commitment, err := fs.LoadCommitment("team", "s1")
+	if err != nil {
+		t.Fatalf("LoadCommitment: %v", err)
+	}
+	if commitment.Project.CurrentSprint.Name != "planned" || !commitment.TakenAt.Equal(base) {
+		t.Errorf("commitment = %+v, want the planned sprint taken at %v", commitment, base)
+	}
+}
+
+func TestLoadRange(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+	saveDays(t, fs, "team", 5)
+
+	snapshots, err := fs.LoadRange("team", base.AddDate(0, 0, 1), base.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatalf("LoadRange: %v", err)
+	}
+	got := ""
+	for _, s := range snapshots {
+		got += s.Project.CurrentSprint.Name
+	}
+	if got != "bcd" {
+		t.Errorf("LoadRange = %q, want inclusive bounds bcd", got)
+	}
+}
+
+func TestPathsStayUnderRoot(t *testing.T) {
+	root := t.TempDir()
+	fs := NewFileSystem(root)
+
+	for _, team := range []string{"../x", "a/../../b", `..\x`, ".", ""} {
+		t.Run(team, func(t *testing.T) {
+			info, err := fs.Save(team, project("Sprint 1"), base)
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			if err := fs.SaveCommitment(team, "../../s1", project("Sprint 1"), base); err != nil {
+				t.Fatalf("SaveCommitment: %v", err)
+			}
+
+			for _, path := range []string{info.Path, fs.commitmentPath(team, "../../s1"), fs.ReportsDir(team)} {
+				rel, err := filepath.Rel(root, path)
+				if err != nil || strings.HasPrefix(rel, "..") || !strings.Contains(rel, string(filepath.Separator)) {
+					t.Errorf("%s escapes the team directory under %s", path, root)
+				}
+			}
+
+			if infos, err := fs.List(team); err != nil || len(infos) != 1 {
+				t.Errorf("List(%q) = %v, %v, want the saved snapshot", team, infos, err)
+			}
+		})
+	}
+}